@@ -18,6 +18,8 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -112,6 +114,10 @@ func Import(provider terraformutils.ProviderGenerator, options ImportOptions, ar
 		options.Resources = localSlice
 	}
 
+	if hook, ok := provider.(terraformutils.ServiceOrderHook); ok {
+		options.Resources = hook.OrderServices(options.Resources)
+	}
+
 	providerWrapper, err := providerwrapper.NewProviderWrapper(provider.GetName(), provider.GetConfig(), options.Verbose)
 	if err != nil {
 		return err
@@ -127,6 +133,11 @@ func Import(provider terraformutils.ProviderGenerator, options ImportOptions, ar
 		}
 		plan.ImportedResource[service] = append(plan.ImportedResource[service], resources...)
 	}
+	if hook, ok := provider.(terraformutils.PostImportHook); ok {
+		if err := hook.PostImportHook(plan.ImportedResource); err != nil {
+			return err
+		}
+	}
 	if options.Plan {
 		path := Path(options.PathPattern, provider.GetName(), "terraformer", options.PathOutput)
 		return ExportPlanFile(plan, path, "plan.json")
@@ -181,6 +192,10 @@ func ImportFromPlan(provider terraformutils.ProviderGenerator, plan *ImportPlan)
 		log.Println(provider.GetName() + " Connecting.... ")
 		importedResource = terraformutils.ConnectServices(importedResource, isServicePath, provider.GetResourceConnections())
 	}
+	// The services printed in a single root are linked without remote states
+	if hook, ok := provider.(terraformutils.ConnectHook); ok && (options.Connect || !isServicePath) {
+		hook.ConnectHook(importedResource, isServicePath)
+	}
 
 	if !isServicePath {
 		var compactedResources []terraformutils.Resource
@@ -199,13 +214,40 @@ func ImportFromPlan(provider terraformutils.ProviderGenerator, plan *ImportPlan)
 			}
 		}
 	}
+	if hook, ok := provider.(terraformutils.OutputHook); ok {
+		return hook.OutputHook(filepath.Clean(Path(options.PathPattern, provider.GetName(), "", options.PathOutput)))
+	}
 	return nil
 }
 
 func printService(provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, resources []terraformutils.Resource, importedResource map[string][]terraformutils.Resource) error {
 	log.Println(provider.GetName() + " save " + serviceName)
-	// Print HCL files for Resources
 	path := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
+	if hook, ok := provider.(terraformutils.SplitPathHook); ok {
+		for splitPath, splitResources := range hook.SplitPath(path, resources) {
+			if err := printServicePath(provider, serviceName, options, splitPath, splitResources, importedResource); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return printServicePath(provider, serviceName, options, path, resources, importedResource)
+}
+
+func printServicePath(provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, path string, resources []terraformutils.Resource, importedResource map[string][]terraformutils.Resource) error {
+	// Print HCL files for Resources
+	if hook, ok := provider.(terraformutils.PrintHook); ok {
+		var err error
+		if resources, err = hook.PrintHook(path, options.Output, resources); err != nil {
+			return err
+		}
+	}
+	// The references to the connected services are named after the roots
+	// they are read from, before the resources are printed
+	remoteStates := map[string]string{}
+	if serviceName != "" && options.Connect {
+		remoteStates = connectedRemoteStates(provider, options, serviceName, resources, importedResource)
+	}
 	err := terraformoutput.OutputHclFiles(resources, provider, path, serviceName, options.Compact, options.Output)
 	if err != nil {
 		return err
@@ -239,7 +281,7 @@ func printService(provider terraformutils.ProviderGenerator, serviceName string,
 	}
 	// Print hcl variables.tf
 	if serviceName != "" {
-		if options.Connect && len(provider.GetResourceConnections()[serviceName]) > 0 {
+		if options.Connect && len(remoteStates) > 0 {
 			variables := map[string]map[string]map[string]interface{}{}
 			variables["data"] = map[string]map[string]interface{}{}
 			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
@@ -247,30 +289,24 @@ func printService(provider terraformutils.ProviderGenerator, serviceName string,
 				bucket := terraformoutput.BucketState{
 					Name: options.Bucket,
 				}
-				for k := range provider.GetResourceConnections()[serviceName] {
-					if _, exist := importedResource[k]; !exist {
-						continue
-					}
+				for k, statePath := range remoteStates {
 					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
 						"backend": "gcs",
-						"config":  bucket.BucketGetTfData(strings.ReplaceAll(path, serviceName, k)),
+						"config":  bucket.BucketGetTfData(statePath),
 					}
 				}
 			} else {
-				for k := range provider.GetResourceConnections()[serviceName] {
-					if _, exist := importedResource[k]; !exist {
-						continue
-					}
+				for k, statePath := range remoteStates {
 					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
 						"backend": "local",
 						"config": [1]interface{}{map[string]interface{}{
-							"path": strings.Repeat("../", strings.Count(path, "/")) + strings.ReplaceAll(path, serviceName, k) + "terraform.tfstate",
+							"path": strings.Repeat("../", strings.Count(withTrailingSlash(path), "/")) + withTrailingSlash(statePath) + "terraform.tfstate",
 						}},
 					}
 				}
 			}
 			// create variables file
-			if len(provider.GetResourceConnections()[serviceName]) > 0 && options.Connect && len(variables["data"]["terraform_remote_state"]) > 0 {
+			if len(variables["data"]["terraform_remote_state"]) > 0 {
 				variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output)
 				if err != nil {
 					return err
@@ -312,6 +348,150 @@ func printService(provider terraformutils.ProviderGenerator, serviceName string,
 	return nil
 }
 
+// withTrailingSlash return path ending with a single slash, counting its
+// slashes then gives its depth
+func withTrailingSlash(path string) string {
+	return strings.TrimSuffix(path, "/") + "/"
+}
+
+var unsafeRemoteStateChars = regexp.MustCompile(`[^0-9A-Za-z_]+`)
+
+// connectedRemoteStates return the output path of each connected service
+// read by resources, by remote state name. When the provider splits a
+// connected service across several roots, one remote state is read per root
+// holding referenced resources, named after the root, and the references of
+// resources are renamed accordingly. A reference to the whole remote state,
+// as in depends_on, is replaced by a reference to each root.
+func connectedRemoteStates(provider terraformutils.ProviderGenerator, options ImportOptions, serviceName string, resources []terraformutils.Resource, importedResource map[string][]terraformutils.Resource) map[string]string {
+	remoteStates := map[string]string{}
+	connected := map[string]bool{}
+	for k := range provider.GetResourceConnections()[serviceName] {
+		connected[k] = true
+	}
+	// The services linked by a ConnectHook are read when referenced
+	for k := range importedResource {
+		if !connected[k] && readsRemoteState(resources, k) {
+			connected[k] = true
+		}
+	}
+	for k := range connected {
+		if _, exist := importedResource[k]; !exist {
+			continue
+		}
+		path := Path(options.PathPattern, provider.GetName(), k, options.PathOutput)
+		groups := map[string][]terraformutils.Resource{path: importedResource[k]}
+		if hook, ok := provider.(terraformutils.SplitPathHook); ok {
+			groups = hook.SplitPath(path, importedResource[k])
+		}
+		if len(groups) == 1 {
+			for splitPath := range groups {
+				remoteStates[k] = splitPath
+			}
+			continue
+		}
+
+		// The outputs of the connected resources are named <type>_<name>_<key>
+		outputPaths := map[string]string{}
+		splitPaths := make([]string, 0, len(groups))
+		for splitPath, splitResources := range groups {
+			for _, r := range splitResources {
+				outputPaths[r.InstanceInfo.Type+"_"+r.ResourceName+"_"] = splitPath
+			}
+			splitPaths = append(splitPaths, splitPath)
+		}
+		sort.Strings(splitPaths)
+		remoteStateName := func(splitPath string) string {
+			suffix := strings.Trim(strings.TrimPrefix(splitPath, withTrailingSlash(path)), "/")
+			if suffix == "" {
+				return k
+			}
+			return k + "_" + unsafeRemoteStateChars.ReplaceAllString(suffix, "_")
+		}
+
+		remoteState := "data.terraform_remote_state." + k
+		referenced := regexp.MustCompile(regexp.QuoteMeta(remoteState) + `\.outputs\.([0-9A-Za-z_-]+)`)
+		rewrite := func(value string) []string {
+			if value == remoteState {
+				values := make([]string, 0, len(splitPaths))
+				for _, splitPath := range splitPaths {
+					remoteStates[remoteStateName(splitPath)] = splitPath
+					values = append(values, "data.terraform_remote_state."+remoteStateName(splitPath))
+				}
+				return values
+			}
+			return []string{referenced.ReplaceAllStringFunc(value, func(reference string) string {
+				output := referenced.FindStringSubmatch(reference)[1]
+				prefix := ""
+				for outputPrefix := range outputPaths {
+					if strings.HasPrefix(output, outputPrefix) && len(outputPrefix) > len(prefix) {
+						prefix = outputPrefix
+					}
+				}
+				if prefix == "" {
+					log.Printf("%s: no %s root outputs %s", provider.GetName(), k, output)
+					return reference
+				}
+				name := remoteStateName(outputPaths[prefix])
+				remoteStates[name] = outputPaths[prefix]
+				return "data.terraform_remote_state." + name + ".outputs." + output
+			})}
+		}
+		for i := range resources {
+			rewriteStrings(resources[i].Item, rewrite)
+		}
+	}
+	return remoteStates
+}
+
+// readsRemoteState return whether resources refer to the remote state k
+func readsRemoteState(resources []terraformutils.Resource, k string) bool {
+	reference := regexp.MustCompile(`data\.terraform_remote_state\.` + regexp.QuoteMeta(k) + `(\.|$)`)
+	found := false
+	for _, r := range resources {
+		rewriteStrings(r.Item, func(value string) []string {
+			found = found || reference.MatchString(value)
+			return []string{value}
+		})
+	}
+	return found
+}
+
+// rewriteStrings replace in place the strings held by the maps and lists of
+// value by rewrite, a string of a list may be replaced by several
+func rewriteStrings(value interface{}, rewrite func(string) []string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if values := rewrite(v); len(values) == 1 {
+			return values[0]
+		}
+		return v
+	case map[string]interface{}:
+		for key, e := range v {
+			v[key] = rewriteStrings(e, rewrite)
+		}
+		return v
+	case []interface{}:
+		rewritten := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				for _, value := range rewrite(s) {
+					rewritten = append(rewritten, value)
+				}
+				continue
+			}
+			rewritten = append(rewritten, rewriteStrings(e, rewrite))
+		}
+		return rewritten
+	case []string:
+		rewritten := make([]string, 0, len(v))
+		for _, e := range v {
+			rewritten = append(rewritten, rewrite(e)...)
+		}
+		return rewritten
+	}
+	return value
+}
+
 func Path(pathPattern, providerName, serviceName, output string) string {
 	return strings.NewReplacer(
 		"{provider}", providerName,
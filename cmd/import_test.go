@@ -0,0 +1,217 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	datadog_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/datadog"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// importConnected print importedResource with --connect from a temporary
+// working directory, with the datadog provider configured by env, and
+// return the content of the variables.tf written to path
+func importConnected(t *testing.T, env map[string]string, importedResource map[string][]terraformutils.Resource, path string) string {
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	provider := &datadog_terraforming.DatadogProvider{}
+	if err := provider.Init([]string{"api-key", "app-key", ""}); err != nil {
+		t.Fatal(err)
+	}
+	err = ImportFromPlan(provider, &ImportPlan{
+		Provider: provider.GetName(),
+		Options: ImportOptions{
+			PathPattern: DefaultPathPattern,
+			PathOutput:  DefaultPathOutput,
+			State:       DefaultState,
+			Output:      "hcl",
+			Connect:     true,
+		},
+		ImportedResource: importedResource,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	variables, err := ioutil.ReadFile(path + "variables.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(variables)
+}
+
+func TestImportConnectedTeamSplit(t *testing.T) {
+	monitor := terraformutils.NewResource("1", "monitor_1", "datadog_monitor", "datadog", map[string]string{
+		"id":                 "1",
+		"name":               "cpu",
+		"tags.#":             "1",
+		"tags.0":             "team:core",
+		"restricted_roles.#": "1",
+		"restricted_roles.0": "role-1",
+	}, []string{}, map[string]interface{}{})
+	monitor.Item = map[string]interface{}{
+		"name":             "cpu",
+		"tags":             []interface{}{"team:core"},
+		"restricted_roles": []interface{}{"role-1"},
+	}
+	role := terraformutils.NewResource("role-1", "role_1", "datadog_role", "datadog", map[string]string{
+		"id":   "role-1",
+		"name": "admin",
+	}, []string{}, map[string]interface{}{})
+	role.Item = map[string]interface{}{"name": "admin"}
+
+	variables := importConnected(t, map[string]string{"DATADOG_GROUP_BY_TEAM": "true"}, map[string][]terraformutils.Resource{
+		"monitor": {monitor},
+		"role":    {role},
+	}, "generated/datadog/monitor/core/")
+
+	// the team root is one level deeper than the service root, the roles
+	// without team tag stay in the role service root
+	if !strings.Contains(variables, `"../../../../generated/datadog/role/terraform.tfstate"`) {
+		t.Errorf("unexpected remote state path:\n%s", variables)
+	}
+}
+
+func TestImportConnectedEnvAndTeamSplit(t *testing.T) {
+	test := terraformutils.NewResource("abc-def-ghi", "synthetics_abc", "datadog_synthetics_test", "datadog", map[string]string{
+		"id":          "abc-def-ghi",
+		"name":        "checkout",
+		"tags.#":      "2",
+		"tags.0":      "env:prod",
+		"tags.1":      "team:core",
+		"locations.#": "2",
+		"locations.0": "aws:us-east-1",
+		"locations.1": "pl:prod-123",
+	}, []string{}, map[string]interface{}{})
+	test.Item = map[string]interface{}{
+		"name":      "checkout",
+		"tags":      []interface{}{"env:prod", "team:core"},
+		"locations": []interface{}{"aws:us-east-1", "pl:prod-123"},
+	}
+	newLocation := func(id, env string) terraformutils.Resource {
+		location := terraformutils.NewResource(id, "synthetics_private_location_"+env, "datadog_synthetics_private_location", "datadog", map[string]string{
+			"id":     id,
+			"name":   env,
+			"tags.#": "1",
+			"tags.0": "env:" + env,
+		}, []string{}, map[string]interface{}{})
+		location.Item = map[string]interface{}{"name": env, "tags": []interface{}{"env:" + env}}
+		return location
+	}
+
+	variables := importConnected(t, map[string]string{
+		"DATADOG_WORKSPACE_PER_ENV": "true",
+		"DATADOG_GROUP_BY_TEAM":     "true",
+	}, map[string][]terraformutils.Resource{
+		"synthetics":                  {test},
+		"synthetics_private_location": {newLocation("pl:prod-123", "prod"), newLocation("pl:staging-456", "staging")},
+	}, "generated/datadog/synthetics/prod/core/")
+
+	// the environment then team roots are two levels deeper than the service
+	// root, the referenced location is in the prod root of its service
+	if !strings.Contains(variables, `"../../../../../generated/datadog/synthetics_private_location/prod/terraform.tfstate"`) {
+		t.Errorf("unexpected remote state path:\n%s", variables)
+	}
+}
+
+func TestImportConnectedAcrossRoots(t *testing.T) {
+	test := terraformutils.NewResource("abc-def-ghi", "synthetics_abc", "datadog_synthetics_test", "datadog", map[string]string{
+		"id":          "abc-def-ghi",
+		"name":        "checkout",
+		"locations.#": "2",
+		"locations.0": "pl:prod-123",
+		"locations.1": "pl:staging-456",
+	}, []string{}, map[string]interface{}{})
+	test.Item = map[string]interface{}{
+		"name":      "checkout",
+		"locations": []interface{}{"pl:prod-123", "pl:staging-456"},
+	}
+	newLocation := func(id, env string) terraformutils.Resource {
+		location := terraformutils.NewResource(id, "synthetics_private_location_"+env, "datadog_synthetics_private_location", "datadog", map[string]string{
+			"id":     id,
+			"name":   env,
+			"tags.#": "1",
+			"tags.0": "env:" + env,
+		}, []string{}, map[string]interface{}{})
+		location.Item = map[string]interface{}{"name": env, "tags": []interface{}{"env:" + env}}
+		return location
+	}
+
+	path := "generated/datadog/synthetics/"
+	variables := importConnected(t, map[string]string{"DATADOG_WORKSPACE_PER_ENV": "true"}, map[string][]terraformutils.Resource{
+		"synthetics":                  {test},
+		"synthetics_private_location": {newLocation("pl:prod-123", "prod"), newLocation("pl:staging-456", "staging")},
+	}, path)
+
+	// the test runs in locations of both environments, each environment root
+	// is read by a remote state of its own
+	for _, env := range []string{"prod", "staging"} {
+		if !strings.Contains(variables, `"../../../generated/datadog/synthetics_private_location/`+env+`/terraform.tfstate"`) {
+			t.Errorf("missing the %s remote state:\n%s", env, variables)
+		}
+	}
+	resources, err := ioutil.ReadFile(path + "synthetics_test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, reference := range []string{
+		"data.terraform_remote_state.synthetics_private_location_prod.outputs.datadog_synthetics_private_location_tfer--synthetics_private_location_prod_id",
+		"data.terraform_remote_state.synthetics_private_location_staging.outputs.datadog_synthetics_private_location_tfer--synthetics_private_location_staging_id",
+	} {
+		if !strings.Contains(string(resources), reference) {
+			t.Errorf("missing %s:\n%s", reference, resources)
+		}
+	}
+}
+
+func TestImportConnectedHookReferences(t *testing.T) {
+	customVariable := terraformutils.NewResource("TOKEN", "TOKEN", "datadog_webhook_custom_variable", "datadog", map[string]string{
+		"id":        "TOKEN",
+		"name":      "TOKEN",
+		"is_secret": "false",
+		"value":     "abc",
+	}, []string{}, map[string]interface{}{})
+	customVariable.Item = map[string]interface{}{"name": "TOKEN", "is_secret": "false", "value": "abc"}
+	webhook := terraformutils.NewResource("alerts", "alerts", "datadog_webhook", "datadog", map[string]string{
+		"id":   "alerts",
+		"name": "alerts",
+		"url":  "https://example.com/$TOKEN",
+	}, []string{}, map[string]interface{}{})
+	webhook.Item = map[string]interface{}{"name": "alerts", "url": "https://example.com/$TOKEN"}
+
+	variables := importConnected(t, nil, map[string][]terraformutils.Resource{
+		"webhook":                 {webhook},
+		"webhook_custom_variable": {customVariable},
+	}, "generated/datadog/webhook/")
+
+	// the custom variables are no resource connection of the webhooks, their
+	// remote state is read as the webhook depends on it
+	if !strings.Contains(variables, `"../../../generated/datadog/webhook_custom_variable/terraform.tfstate"`) {
+		t.Errorf("missing the custom variable remote state:\n%s", variables)
+	}
+}
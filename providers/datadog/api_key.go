@@ -0,0 +1,116 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// APIKeyAllowEmptyValues ...
+	APIKeyAllowEmptyValues = []string{}
+)
+
+type apiKey struct {
+	ID string `json:"id"`
+}
+
+type apiKeysResponse struct {
+	Data []apiKey `json:"data"`
+}
+
+// listAPIKeys page through the V2 API keys API
+func listAPIKeys(client *datadogV2.APIClient, auth context.Context) ([]apiKey, error) {
+	var keys []apiKey
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		var resp apiKeysResponse
+		err := getV2(client, auth, "/api/v2/api_keys", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return keys, nil
+		}
+	}
+}
+
+// APIKeyGenerator ...
+type APIKeyGenerator struct {
+	DatadogService
+}
+
+func (g *APIKeyGenerator) createResources(keys []apiKey) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, key := range keys {
+		resources = append(resources, g.createResource(key.ID))
+	}
+
+	return resources
+}
+
+func (g *APIKeyGenerator) createResource(keyID string) terraformutils.Resource {
+	resource := terraformutils.NewSimpleResource(
+		keyID,
+		fmt.Sprintf("api_key_%s", keyID),
+		"datadog_api_key",
+		"datadog",
+		APIKeyAllowEmptyValues,
+	)
+	// The key is a secret, it is kept in the state but never written to the
+	// configuration
+	resource.IgnoreKeys = append(resource.IgnoreKeys, "^key$")
+	return resource
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each API key create 1 TerraformResource.
+// Need API Key ID as ID for terraform resource
+func (g *APIKeyGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("api_key") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	keys, err := listAPIKeys(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(keys)
+	return nil
+}
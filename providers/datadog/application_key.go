@@ -0,0 +1,156 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// ApplicationKeyAllowEmptyValues ...
+	ApplicationKeyAllowEmptyValues = []string{}
+)
+
+type applicationKey struct {
+	ID            string `json:"id"`
+	Relationships struct {
+		OwnedBy struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"owned_by"`
+	} `json:"relationships"`
+}
+
+type applicationKeysResponse struct {
+	Data []applicationKey `json:"data"`
+}
+
+// listApplicationKeys page through the V2 application keys API of the
+// organization
+func listApplicationKeys(client *datadogV2.APIClient, auth context.Context) ([]applicationKey, error) {
+	var keys []applicationKey
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		var resp applicationKeysResponse
+		err := getV2(client, auth, "/api/v2/application_keys", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return keys, nil
+		}
+	}
+}
+
+// ApplicationKeyGenerator ...
+type ApplicationKeyGenerator struct {
+	DatadogService
+}
+
+func (g *ApplicationKeyGenerator) createResources(keys []applicationKey) []terraformutils.Resource {
+	owners, _ := g.Args["application-key-owners"].(map[string]string)
+	resources := []terraformutils.Resource{}
+	for _, key := range keys {
+		if owners != nil && key.Relationships.OwnedBy.Data.ID != "" {
+			owners[key.ID] = key.Relationships.OwnedBy.Data.ID
+		}
+		resources = append(resources, g.createResource(key.ID))
+	}
+
+	return resources
+}
+
+func (g *ApplicationKeyGenerator) createResource(keyID string) terraformutils.Resource {
+	resource := terraformutils.NewSimpleResource(
+		keyID,
+		fmt.Sprintf("application_key_%s", keyID),
+		"datadog_application_key",
+		"datadog",
+		ApplicationKeyAllowEmptyValues,
+	)
+	// The key is a secret, it is kept in the state but never written to the
+	// configuration
+	resource.IgnoreKeys = append(resource.IgnoreKeys, "^key$")
+	return resource
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each application key create 1 TerraformResource.
+// Need Application Key ID as ID for terraform resource
+func (g *ApplicationKeyGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("application_key") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	keys, err := listApplicationKeys(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(keys)
+	return nil
+}
+
+// linkApplicationKeyOwners make the application keys depend on their owning
+// user or service account exported with them, the datadog_application_key
+// resource has no owner attribute to reference
+func linkApplicationKeyOwners(importedResource map[string][]terraformutils.Resource, owners map[string]string, isServicePath bool) {
+	type owner struct {
+		service  string
+		resource terraformutils.Resource
+	}
+	exportedOwners := map[string]owner{}
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type == "datadog_user" || r.InstanceInfo.Type == "datadog_service_account" {
+				exportedOwners[r.InstanceState.ID] = owner{service, r}
+			}
+		}
+	}
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type != "datadog_application_key" {
+				continue
+			}
+			if o, ok := exportedOwners[owners[r.InstanceState.ID]]; ok {
+				r.Item["depends_on"] = []interface{}{connectedAddress(service, o.service, o.resource, isServicePath)}
+			}
+		}
+	}
+}
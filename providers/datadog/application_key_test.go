@@ -0,0 +1,85 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestApplicationKeyOwnersAndSecret(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/application_keys" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "key-1", "type": "application_keys", "attributes": {"name": "ci"}, "relationships": {"owned_by": {"data": {"id": "sa-1", "type": "users"}}}},
+			{"id": "key-2", "type": "application_keys", "attributes": {"name": "other"}, "relationships": {"owned_by": {"data": {"id": "user-2", "type": "users"}}}}
+		]}`))
+	}))
+
+	owners := map[string]string{}
+	g := &ApplicationKeyGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":                 auth,
+		"datadogClientV2":        client,
+		"application-key-owners": owners,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(owners, map[string]string{"key-1": "sa-1", "key-2": "user-2"}) {
+		t.Errorf("unexpected application key owners %v", owners)
+	}
+
+	// the secret key stays in the state only
+	key := g.Resources[0]
+	key.InstanceState.Attributes = map[string]string{"id": "key-1", "name": "ci", "key": "0123456789abcdef"}
+	parseTestState(t, &key, cty.Object(map[string]cty.Type{"name": cty.String, "key": cty.String}))
+	if _, ok := key.Item["key"]; ok || key.Item["name"] != "ci" {
+		t.Errorf("expected only the key metadata in the configuration, got %v", key.Item)
+	}
+	if key.InstanceState.Attributes["key"] == "" {
+		t.Errorf("expected the key to be kept in the state")
+	}
+
+	serviceAccount := terraformutils.NewSimpleResource("sa-1", "service_account_sa-1", "datadog_service_account", "datadog", ServiceAccountAllowEmptyValues)
+	other := g.Resources[1]
+	other.Item = map[string]interface{}{"name": "other"}
+	importedResource := map[string][]terraformutils.Resource{
+		"application_key": {key, other},
+		"service_account": {serviceAccount},
+	}
+
+	// printed apart, the key reads its owner from the service account root
+	linkApplicationKeyOwners(importedResource, owners, true)
+	if !reflect.DeepEqual(key.Item["depends_on"], []interface{}{"data.terraform_remote_state.service_account"}) {
+		t.Errorf("expected the key to depend on the service account remote state, got %v", key.Item["depends_on"])
+	}
+	if _, ok := other.Item["depends_on"]; ok {
+		t.Errorf("unexpected dependency on an owner not exported")
+	}
+
+	linkApplicationKeyOwners(importedResource, owners, false)
+	if !reflect.DeepEqual(key.Item["depends_on"], []interface{}{"datadog_service_account.tfer--service_account_sa-002D-1"}) {
+		t.Errorf("expected the key to depend on its service account, got %v", key.Item["depends_on"])
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// AuthNMappingAllowEmptyValues ...
+	AuthNMappingAllowEmptyValues = []string{}
+)
+
+type authNMapping struct {
+	ID            string `json:"id"`
+	Relationships struct {
+		Role *struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"role"`
+	} `json:"relationships"`
+}
+
+type authNMappingsResponse struct {
+	Data []authNMapping `json:"data"`
+}
+
+// listAuthNMappings page through the V2 authentication mappings API
+func listAuthNMappings(client *datadogV2.APIClient, auth context.Context) ([]authNMapping, error) {
+	var mappings []authNMapping
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		var resp authNMappingsResponse
+		err := getV2(client, auth, "/api/v2/authn_mappings", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return mappings, nil
+		}
+	}
+}
+
+// AuthNMappingGenerator ...
+type AuthNMappingGenerator struct {
+	DatadogService
+}
+
+func (g *AuthNMappingGenerator) createResources(mappings []authNMapping) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, mapping := range mappings {
+		// The SAML default mappings don't grant a role, they can't be
+		// managed by the datadog_authn_mapping resource
+		if mapping.Relationships.Role == nil || mapping.Relationships.Role.Data.ID == "" {
+			continue
+		}
+		resources = append(resources, g.createResource(mapping.ID))
+	}
+
+	return resources
+}
+
+func (g *AuthNMappingGenerator) createResource(mappingID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		mappingID,
+		fmt.Sprintf("authn_mapping_%s", mappingID),
+		"datadog_authn_mapping",
+		"datadog",
+		AuthNMappingAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each authentication mapping create 1 TerraformResource.
+// Need AuthN Mapping ID as ID for terraform resource
+func (g *AuthNMappingGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("authn_mapping") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	mappings, err := listAuthNMappings(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(mappings)
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAuthNMappingPaginationSkipsDefaults(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/authn_mappings" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page[number]") != "0" {
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "last", "type": "authn_mappings", "relationships": {"role": {"data": {"id": "role-2", "type": "roles"}}}},
+				{"id": "default", "type": "authn_mappings", "relationships": {}}
+			]}`))
+			return
+		}
+		mappings := make([]string, 100)
+		for i := range mappings {
+			mappings[i] = fmt.Sprintf(`{"id": "m%d", "type": "authn_mappings", "relationships": {"role": {"data": {"id": "role-1", "type": "roles"}}}}`, i)
+		}
+		_, _ = w.Write([]byte(`{"data": [` + strings.Join(mappings, ",") + `]}`))
+	}))
+
+	g := &AuthNMappingGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 101 {
+		t.Fatalf("expected 101 authn mappings, got %d", len(g.Resources))
+	}
+	last := g.Resources[100]
+	if last.InstanceState.ID != "last" || last.InstanceInfo.Type != "datadog_authn_mapping" {
+		t.Errorf("unexpected authn mapping %s %s", last.InstanceInfo.Type, last.InstanceState.ID)
+	}
+	for _, r := range g.Resources {
+		if r.InstanceState.ID == "default" {
+			t.Errorf("unexpected SAML default mapping")
+		}
+	}
+}
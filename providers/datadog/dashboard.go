@@ -36,6 +36,11 @@ type DashboardGenerator struct {
 func (g *DashboardGenerator) createResources(dashboards []datadogV1.DashboardSummaryDashboards) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, dashboard := range dashboards {
+		// The dashboards list has no modified-since parameter nor sort, an
+		// incremental export lists them all and filters them here
+		if !g.isOwned(dashboard.GetAuthorHandle()) || !g.isModified(dashboard.GetModifiedAt()) {
+			continue
+		}
 		resourceName := dashboard.GetId()
 		resources = append(resources, g.createResource(resourceName))
 	}
@@ -0,0 +1,169 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// DashboardJSONAllowEmptyValues ...
+	DashboardJSONAllowEmptyValues = []string{}
+
+	// dashboardJSONComputedFields list the dashboard fields set by Datadog,
+	// left out of the dashboard JSON so it applies cleanly on another org
+	dashboardJSONComputedFields = []string{
+		"author_handle", "author_name", "created_at", "id", "modified_at", "url",
+	}
+)
+
+// stripDashboardComputedFields remove the computed fields of dashboard and
+// the ids of its widgets, including the widgets nested in group widgets
+func stripDashboardComputedFields(dashboard map[string]interface{}) {
+	for _, field := range dashboardJSONComputedFields {
+		delete(dashboard, field)
+	}
+	stripWidgetIDs(dashboard["widgets"])
+}
+
+func stripWidgetIDs(widgets interface{}) {
+	list, ok := widgets.([]interface{})
+	if !ok {
+		return
+	}
+	for _, widget := range list {
+		widget, ok := widget.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		delete(widget, "id")
+		if definition, ok := widget["definition"].(map[string]interface{}); ok {
+			stripWidgetIDs(definition["widgets"])
+		}
+	}
+}
+
+// DashboardJSONGenerator ...
+type DashboardJSONGenerator struct {
+	DatadogService
+	dashboards map[string]string
+}
+
+// createResources create a resource from each raw dashboard, keeping its JSON
+// without the computed fields
+func (g *DashboardJSONGenerator) createResources(rawDashboards []json.RawMessage) ([]terraformutils.Resource, error) {
+	resources := []terraformutils.Resource{}
+	g.dashboards = map[string]string{}
+	for _, rawDashboard := range rawDashboards {
+		dashboard := map[string]interface{}{}
+		decoder := json.NewDecoder(bytes.NewReader(rawDashboard))
+		// Keep the numbers as written, widget options and markers are numbers
+		decoder.UseNumber()
+		if err := decoder.Decode(&dashboard); err != nil {
+			return nil, err
+		}
+		dashboardID := fmt.Sprint(dashboard["id"])
+		stripDashboardComputedFields(dashboard)
+		var document bytes.Buffer
+		encoder := json.NewEncoder(&document)
+		// Widget queries compare with > and <, keep them readable
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(dashboard); err != nil {
+			return nil, err
+		}
+		g.dashboards[dashboardID] = string(bytes.TrimSpace(document.Bytes()))
+		resources = append(resources, g.createResource(dashboardID))
+	}
+
+	return resources, nil
+}
+
+func (g *DashboardJSONGenerator) createResource(dashboardID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		dashboardID,
+		fmt.Sprintf("dashboard_json_%s", dashboardID),
+		"datadog_dashboard_json",
+		"datadog",
+		DashboardJSONAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each dashboard create 1 TerraformResource holding the dashboard JSON.
+// Need Dashboard ID as ID for terraform resource
+func (g *DashboardJSONGenerator) InitResources() error {
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	var dashboardIDs []string
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && (filter.IsApplicable("dashboard_json") || filter.IsApplicable("dashboard")) {
+			dashboardIDs = append(dashboardIDs, filter.AcceptableValues...)
+		}
+	}
+
+	if len(dashboardIDs) == 0 {
+		var resp struct {
+			Dashboards []struct {
+				ID string `json:"id"`
+			} `json:"dashboards"`
+		}
+		if err := getV1(datadogClientV1, authV1, "/api/v1/dashboard", url.Values{}, &resp); err != nil {
+			return err
+		}
+		for _, dashboard := range resp.Dashboards {
+			dashboardIDs = append(dashboardIDs, dashboard.ID)
+		}
+	}
+
+	// The list only has the dashboard summaries, each definition is fetched
+	rawDashboards := make([]json.RawMessage, 0, len(dashboardIDs))
+	for _, dashboardID := range dashboardIDs {
+		var rawDashboard json.RawMessage
+		if err := getV1(datadogClientV1, authV1, "/api/v1/dashboard/"+url.PathEscape(dashboardID), url.Values{}, &rawDashboard); err != nil {
+			return err
+		}
+		rawDashboards = append(rawDashboards, rawDashboard)
+	}
+	resources, err := g.createResources(rawDashboards)
+	if err != nil {
+		return err
+	}
+	g.Resources = resources
+	return nil
+}
+
+// PostConvertHook write the dashboard JSON as returned by the API, without
+// its computed fields, in a heredoc
+func (g *DashboardJSONGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		document, ok := g.dashboards[r.InstanceState.ID]
+		if !ok {
+			continue
+		}
+		g.Resources[i].Item["dashboard"] = fmt.Sprintf(`<<EOF
+%s
+EOF`, escapeTemplateSequences(document))
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStripDashboardComputedFields(t *testing.T) {
+	dashboard := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(`{
+		"id": "abc-def-ghi",
+		"title": "Shop",
+		"author_handle": "jane@example.com",
+		"url": "/dashboard/abc-def-ghi/shop",
+		"created_at": "2021-01-01T00:00:00.000000+00:00",
+		"modified_at": "2021-01-02T00:00:00.000000+00:00",
+		"layout_type": "ordered",
+		"widgets": [
+			{"id": 1, "definition": {"type": "note", "content": "hello"}},
+			{"id": 2, "definition": {"type": "group", "layout_type": "ordered", "widgets": [
+				{"id": 3, "definition": {"type": "timeseries", "requests": [{"q": "avg:system.load.1{*}"}]}},
+				{"id": 4, "definition": {"type": "group", "layout_type": "ordered", "widgets": [
+					{"id": 5, "definition": {"type": "query_value", "requests": [{"q": "sum:orders{*}"}]}}
+				]}}
+			]}}
+		]
+	}`), &dashboard); err != nil {
+		t.Fatal(err)
+	}
+
+	stripDashboardComputedFields(dashboard)
+	document, err := json.Marshal(dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"id"`, `"author_handle"`, `"url"`, `"created_at"`, `"modified_at"`} {
+		if strings.Contains(string(document), field) {
+			t.Errorf("expected %s to be stripped, got %s", field, document)
+		}
+	}
+	for _, kept := range []string{`"title":"Shop"`, `"q":"sum:orders{*}"`, `"content":"hello"`} {
+		if !strings.Contains(string(document), kept) {
+			t.Errorf("expected %s to be kept, got %s", kept, document)
+		}
+	}
+}
+
+func TestDashboardJSONGenerator(t *testing.T) {
+	authored := `{
+		"title": "Checkout",
+		"layout_type": "ordered",
+		"template_variables": [{"name": "env", "prefix": "env", "default": "prod"}],
+		"widgets": [
+			{"definition": {"type": "timeseries", "requests": [{"q": "sum:checkout.errors{$env} > 10"}]}},
+			{"definition": {"type": "group", "layout_type": "ordered", "widgets": [
+				{"definition": {"type": "note", "content": "{{ not a template }}"}}
+			]}}
+		]
+	}`
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/dashboard":
+			_, _ = w.Write([]byte(`{"dashboards": [{"id": "abc-def-ghi", "title": "Checkout"}]}`))
+		case "/api/v1/dashboard/abc-def-ghi":
+			_, _ = w.Write([]byte(`{
+				"id": "abc-def-ghi", "author_handle": "jane@example.com", "url": "/dashboard/abc-def-ghi/checkout",
+				"created_at": "2024-01-01T00:00:00.000000+00:00", "modified_at": "2024-01-02T00:00:00.000000+00:00",
+				"title": "Checkout",
+				"layout_type": "ordered",
+				"template_variables": [{"name": "env", "prefix": "env", "default": "prod"}],
+				"widgets": [
+					{"id": 1, "definition": {"type": "timeseries", "requests": [{"q": "sum:checkout.errors{$env} > 10"}]}},
+					{"id": 2, "definition": {"type": "group", "layout_type": "ordered", "widgets": [
+						{"id": 3, "definition": {"type": "note", "content": "{{ not a template }}"}}
+					]}}
+				]
+			}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	g := &DashboardJSONGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "abc-def-ghi" || g.Resources[0].InstanceInfo.Type != "datadog_dashboard_json" {
+		t.Fatalf("unexpected dashboards %v", g.Resources)
+	}
+	g.Resources[0].Item = map[string]interface{}{}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	heredoc, _ := g.Resources[0].Item["dashboard"].(string)
+	if !strings.HasPrefix(heredoc, "<<EOF\n") || !strings.HasSuffix(heredoc, "\nEOF") {
+		t.Fatalf("expected the dashboard JSON in a heredoc, got %s", heredoc)
+	}
+	var exported, expected interface{}
+	if err := json.Unmarshal([]byte(heredocDocument(heredoc)), &exported); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(authored), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exported, expected) {
+		t.Errorf("expected the authored dashboard\n%v\ngot\n%v", expected, exported)
+	}
+}
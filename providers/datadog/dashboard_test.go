@@ -0,0 +1,190 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestDashboardNotifyListAndRestrictedRoles(t *testing.T) {
+	dashboard := terraformutils.NewSimpleResource("abc-def-ghi", "dashboard_abc-def-ghi", "datadog_dashboard", "datadog", DashboardAllowEmptyValues)
+	dashboard.Item = map[string]interface{}{
+		"notify_list":      []interface{}{"new@example.com"},
+		"restricted_roles": []interface{}{"role-uuid"},
+	}
+	role := terraformutils.NewResource("role-uuid", "role_1", "datadog_role", "datadog", map[string]string{"id": "role-uuid"}, RoleAllowEmptyValues, map[string]interface{}{})
+
+	importedResource := terraformutils.ConnectServices(map[string][]terraformutils.Resource{
+		"dashboard": {dashboard},
+		"role":      {role},
+	}, false, DatadogProvider{}.GetResourceConnections())
+
+	item := importedResource["dashboard"][0].Item
+	if notifyList := item["notify_list"]; !reflect.DeepEqual(notifyList, []interface{}{"new@example.com"}) {
+		t.Errorf("unexpected notify_list %v", notifyList)
+	}
+	expected := []interface{}{"${data.terraform_remote_state.local.outputs.datadog_role_tfer--role_1_id}"}
+	if restrictedRoles := item["restricted_roles"]; !reflect.DeepEqual(restrictedRoles, expected) {
+		t.Errorf("expected restricted_roles %v, got %v", expected, restrictedRoles)
+	}
+}
+
+func TestDashboardOwnedByCurrentUser(t *testing.T) {
+	newDashboard := func(id, author string) datadogV1.DashboardSummaryDashboards {
+		dashboard := datadogV1.DashboardSummaryDashboards{}
+		dashboard.SetId(id)
+		dashboard.SetAuthorHandle(author)
+		return dashboard
+	}
+
+	g := &DashboardGenerator{}
+	g.SetArgs(map[string]interface{}{"owner-handle": "me@example.com"})
+	resources := g.createResources([]datadogV1.DashboardSummaryDashboards{
+		newDashboard("abc-def-ghi", "Me@example.com"),
+		newDashboard("jkl-mno-pqr", "someone@example.com"),
+	})
+
+	if len(resources) != 1 || resources[0].InstanceState.ID != "abc-def-ghi" {
+		t.Errorf("expected only the dashboard authored by the current user, got %v", resources)
+	}
+}
+
+func TestDashboardTargetURL(t *testing.T) {
+	target, err := parseTargetURL("https://app.datadoghq.eu/dashboard/abc-def-ghi/service-overview?from_ts=1&live=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.ServiceName != "dashboard" || !reflect.DeepEqual(target.AcceptableValues, []string{"abc-def-ghi"}) {
+		t.Fatalf("unexpected target %v", target)
+	}
+
+	var requested []string
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "abc-def-ghi", "title": "Service overview", "layout_type": "ordered", "widgets": []}`))
+	}))
+
+	g := &DashboardGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"target":          target,
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	g.ParseFilters(nil)
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(requested, []string{"/api/v1/dashboard/abc-def-ghi"}) {
+		t.Errorf("expected only the targeted dashboard to be fetched, got %v", requested)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "abc-def-ghi" {
+		t.Errorf("expected only the targeted dashboard, got %v", g.Resources)
+	}
+}
+
+func TestDashboardTemplateVariables(t *testing.T) {
+	g := &DashboardGenerator{}
+	resource := g.createResource("abc-def-ghi")
+	resource.InstanceState.Attributes = map[string]string{
+		"id":                                     "abc-def-ghi",
+		"title":                                  "Service overview",
+		"template_variable.#":                    "1",
+		"template_variable.0.name":               "env",
+		"template_variable.0.prefix":             "env",
+		"template_variable.0.default":            "prod",
+		"template_variable.0.available_values.#": "2",
+		"template_variable.0.available_values.0": "prod",
+		"template_variable.0.available_values.1": "staging",
+	}
+	impliedType := cty.Object(map[string]cty.Type{
+		"title": cty.String,
+		"template_variable": cty.List(cty.Object(map[string]cty.Type{
+			"name":             cty.String,
+			"prefix":           cty.String,
+			"default":          cty.String,
+			"available_values": cty.List(cty.String),
+		})),
+	})
+	parseTestState(t, &resource, impliedType)
+
+	expected := []interface{}{map[string]interface{}{
+		"name":             "env",
+		"prefix":           "env",
+		"default":          "prod",
+		"available_values": []interface{}{"prod", "staging"},
+	}}
+	if templateVariables := resource.Item["template_variable"]; !reflect.DeepEqual(templateVariables, expected) {
+		t.Errorf("expected template variables %v, got %v", expected, templateVariables)
+	}
+}
+
+func TestDashboardWidgetCustomLinksAndLiveSpan(t *testing.T) {
+	g := &DashboardGenerator{}
+	resource := g.createResource("abc-def-ghi")
+	resource.InstanceState.Attributes = map[string]string{
+		"id":                                     "abc-def-ghi",
+		"title":                                  "Service overview",
+		"widget.#":                               "1",
+		"widget.0.timeseries_definition.#":       "1",
+		"widget.0.timeseries_definition.0.title": "Requests",
+		"widget.0.timeseries_definition.0.live_span":               "4h",
+		"widget.0.timeseries_definition.0.custom_link.#":           "2",
+		"widget.0.timeseries_definition.0.custom_link.0.label":     "Runbook",
+		"widget.0.timeseries_definition.0.custom_link.0.link":      "https://wiki.example.com/runbook",
+		"widget.0.timeseries_definition.0.custom_link.0.is_hidden": "false",
+		"widget.0.timeseries_definition.0.custom_link.1.label":     "Traces",
+		"widget.0.timeseries_definition.0.custom_link.1.link":      "https://app.datadoghq.com/apm/traces?query={{service.value}}",
+		"widget.0.timeseries_definition.0.custom_link.1.is_hidden": "true",
+	}
+	customLink := cty.Object(map[string]cty.Type{
+		"label":     cty.String,
+		"link":      cty.String,
+		"is_hidden": cty.Bool,
+	})
+	impliedType := cty.Object(map[string]cty.Type{
+		"title": cty.String,
+		"widget": cty.List(cty.Object(map[string]cty.Type{
+			"timeseries_definition": cty.List(cty.Object(map[string]cty.Type{
+				"title":       cty.String,
+				"live_span":   cty.String,
+				"custom_link": cty.List(customLink),
+			})),
+		})),
+	})
+	parseTestState(t, &resource, impliedType)
+
+	expected := []interface{}{map[string]interface{}{
+		"timeseries_definition": []interface{}{map[string]interface{}{
+			"title":     "Requests",
+			"live_span": "4h",
+			"custom_link": []interface{}{
+				map[string]interface{}{"label": "Runbook", "link": "https://wiki.example.com/runbook", "is_hidden": "false"},
+				map[string]interface{}{"label": "Traces", "link": "https://app.datadoghq.com/apm/traces?query={{service.value}}", "is_hidden": "true"},
+			},
+		}},
+	}}
+	if widgets := resource.Item["widget"]; !reflect.DeepEqual(widgets, expected) {
+		t.Errorf("expected widgets %v, got %v", expected, widgets)
+	}
+}
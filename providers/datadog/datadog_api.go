@@ -0,0 +1,135 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+)
+
+// apiError is returned when the Datadog API answers with a non 2xx status code
+type apiError struct {
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s returned %d: %s", e.Path, e.StatusCode, e.Body)
+}
+
+// isNotFound return true if err is a 404 answer from the Datadog API
+func isNotFound(err error) bool {
+	var e *apiError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// isForbidden return true if err is a 403 answer from the Datadog API
+func isForbidden(err error) bool {
+	var e *apiError
+	if errors.As(err, &e) {
+		return e.StatusCode == http.StatusForbidden
+	}
+	var errV1 datadogV1.GenericOpenAPIError
+	if errors.As(err, &errV1) {
+		return strings.HasPrefix(errV1.Error(), "403")
+	}
+	var errV2 datadogV2.GenericOpenAPIError
+	if errors.As(err, &errV2) {
+		return strings.HasPrefix(errV2.Error(), "403")
+	}
+	return false
+}
+
+// getV1 fetch a V1 endpoint which is not covered yet by datadog-api-client-go
+// and decode the JSON response into v
+func getV1(client *datadogV1.APIClient, auth context.Context, path string, query url.Values, v interface{}) error {
+	cfg := client.GetConfig()
+	basePath, err := cfg.ServerURLWithContext(auth, "")
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("User-Agent", cfg.UserAgent)
+	for key, value := range cfg.DefaultHeader {
+		header.Set(key, value)
+	}
+	if keys, ok := auth.Value(datadogV1.ContextAPIKeys).(map[string]datadogV1.APIKey); ok {
+		header.Set("DD-API-KEY", keys["apiKeyAuth"].Key)
+		header.Set("DD-APPLICATION-KEY", keys["appKeyAuth"].Key)
+	}
+	return getJSON(auth, cfg.HTTPClient, basePath, path, query, header, v)
+}
+
+// getV2 fetch a V2 endpoint which is not covered yet by datadog-api-client-go
+// and decode the JSON response into v. The client configuration and auth
+// context are reused so the api-url override keeps working.
+func getV2(client *datadogV2.APIClient, auth context.Context, path string, query url.Values, v interface{}) error {
+	cfg := client.GetConfig()
+	basePath, err := cfg.ServerURLWithContext(auth, "")
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("User-Agent", cfg.UserAgent)
+	for key, value := range cfg.DefaultHeader {
+		header.Set(key, value)
+	}
+	if keys, ok := auth.Value(datadogV2.ContextAPIKeys).(map[string]datadogV2.APIKey); ok {
+		header.Set("DD-API-KEY", keys["apiKeyAuth"].Key)
+		header.Set("DD-APPLICATION-KEY", keys["appKeyAuth"].Key)
+	}
+	return getJSON(auth, cfg.HTTPClient, basePath, path, query, header, v)
+}
+
+// getJSON send a GET request to basePath+path and decode the JSON response into v
+func getJSON(ctx context.Context, httpClient *http.Client, basePath, path string, query url.Values, header http.Header, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, basePath+path, nil)
+	if err != nil {
+		return err
+	}
+	req.URL.RawQuery = query.Encode()
+	req.Header = header
+	req.Header.Set("Accept", "application/json")
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{Path: path, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return json.Unmarshal(body, v)
+}
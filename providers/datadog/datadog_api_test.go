@@ -0,0 +1,99 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+)
+
+// newTestClientV1 return a V1 client and auth context pointing to a test server serving handler
+func newTestClientV1(t *testing.T, handler http.Handler) (*datadogV1.APIClient, context.Context) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := context.WithValue(context.Background(), datadogV1.ContextAPIKeys, map[string]datadogV1.APIKey{
+		"apiKeyAuth": {Key: "api-key"},
+		"appKeyAuth": {Key: "app-key"},
+	})
+	auth = context.WithValue(auth, datadogV1.ContextServerIndex, 1)
+	auth = context.WithValue(auth, datadogV1.ContextServerVariables, map[string]string{
+		"name":     serverURL.Host,
+		"protocol": serverURL.Scheme,
+	})
+	return datadogV1.NewAPIClient(datadogV1.NewConfiguration()), auth
+}
+
+// newTestClientV2 return a V2 client and auth context pointing to a test server serving handler
+func newTestClientV2(t *testing.T, handler http.Handler) (*datadogV2.APIClient, context.Context) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := context.WithValue(context.Background(), datadogV2.ContextAPIKeys, map[string]datadogV2.APIKey{
+		"apiKeyAuth": {Key: "api-key"},
+		"appKeyAuth": {Key: "app-key"},
+	})
+	auth = context.WithValue(auth, datadogV2.ContextServerIndex, 1)
+	auth = context.WithValue(auth, datadogV2.ContextServerVariables, map[string]string{
+		"name":     serverURL.Host,
+		"protocol": serverURL.Scheme,
+	})
+	return datadogV2.NewAPIClient(datadogV2.NewConfiguration()), auth
+}
+
+func TestGetV2(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("DD-API-KEY") != "api-key" || r.Header.Get("DD-APPLICATION-KEY") != "app-key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/api/v2/found" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"id": "abc"}}`))
+	}))
+
+	var resp struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := getV2(client, auth, "/api/v2/found", url.Values{}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.ID != "abc" {
+		t.Errorf("failed to decode response %v", resp)
+	}
+
+	err := getV2(client, auth, "/api/v2/missing", url.Values{}, &resp)
+	if !isNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// connectedAddress return the depends_on address of r, imported as service,
+// from a resource of referrer: the remote state of service when the services
+// are printed apart, r itself otherwise
+func connectedAddress(referrer, service string, r terraformutils.Resource, isServicePath bool) string {
+	if isServicePath && referrer != service {
+		return "data.terraform_remote_state." + service
+	}
+	return r.InstanceInfo.Type + "." + r.ResourceName
+}
+
+// connectedID return the interpolation of the id of r, imported as service,
+// from a resource of referrer
+func connectedID(referrer, service string, r terraformutils.Resource, isServicePath bool) string {
+	if isServicePath && referrer != service {
+		return "${data.terraform_remote_state." + service + ".outputs." + r.InstanceInfo.Type + "_" + r.ResourceName + "_" + r.GetIDKey() + "}"
+	}
+	return "${" + r.InstanceInfo.Type + "." + r.ResourceName + "." + r.GetIDKey() + "}"
+}
+
+// appendAddress append address to dependsOn unless already there
+func appendAddress(dependsOn []interface{}, address string) []interface{} {
+	for _, a := range dependsOn {
+		if a == address {
+			return dependsOn
+		}
+	}
+	return append(dependsOn, address)
+}
+
+// ConnectHook link the resources of the imported services read from each
+// other by id or name only
+func (p *DatadogProvider) ConnectHook(importedResource map[string][]terraformutils.Resource, isServicePath bool) {
+	linkApplicationKeyOwners(importedResource, p.keyOwners, isServicePath)
+	linkWebhookCustomVariables(importedResource, isServicePath)
+	linkPagerdutyServiceObjects(importedResource, isServicePath)
+	linkRestrictionPolicies(importedResource, isServicePath)
+	linkSecurityMonitoringSuppressions(importedResource, isServicePath)
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// externalizedJSONDir is the directory, next to the service files, holding the externalized blobs
+const externalizedJSONDir = "json"
+
+// jsonAttributes list the attributes of each resource type holding a JSON document
+var jsonAttributes = map[string][]string{
+	"datadog_app_builder_app": {"app_json"},
+	"datadog_dashboard_json":  {"dashboard"},
+	"datadog_monitor_json":    {"monitor"},
+}
+
+// externalizeJSON replace the JSON attributes of resources larger than
+// threshold bytes by a file() reference and return the blobs to write, by
+// local name. The local holds the blob path so the reference needs no quoting.
+func externalizeJSON(resources []terraformutils.Resource, threshold int) map[string]string {
+	blobs := map[string]string{}
+	for _, r := range resources {
+		for _, attribute := range jsonAttributes[r.InstanceInfo.Type] {
+			value, ok := r.Item[attribute].(string)
+			if !ok || len(value) <= threshold {
+				continue
+			}
+			name := r.InstanceInfo.Type + "_" + strings.TrimPrefix(r.ResourceName, "tfer--") + "_" + attribute
+			r.Item[attribute] = "${file(local." + name + ")}"
+			blobs[name] = heredocDocument(value)
+		}
+	}
+	return blobs
+}
+
+// heredocDocument return the document of an <<EOF heredoc value, with its
+// template sequences unescaped since file() doesn't interpolate, or value
+// itself when it isn't a heredoc
+func heredocDocument(value string) string {
+	if !strings.HasPrefix(value, "<<EOF\n") || !strings.HasSuffix(value, "\nEOF") {
+		return value
+	}
+	document := strings.TrimSuffix(strings.TrimPrefix(value, "<<EOF\n"), "\nEOF")
+	return strings.NewReplacer("$${", "${", "%%{", "%{").Replace(document)
+}
+
+// writeExternalizedJSON write each blob to its own file under path and the
+// locals pointing to them
+func writeExternalizedJSON(path, output string, blobs map[string]string) error {
+	if len(blobs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path+"/"+externalizedJSONDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(blobs))
+	for name := range blobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	locals := map[string]interface{}{}
+	for _, name := range names {
+		// file() returns the content as is, the blob is written verbatim to avoid a diff
+		if err := ioutil.WriteFile(path+"/"+externalizedJSONDir+"/"+name+".json", []byte(blobs[name]), os.ModePerm); err != nil {
+			return err
+		}
+		locals[name] = "${path.module}/" + externalizedJSONDir + "/" + name + ".json"
+	}
+
+	localsFile, err := terraformutils.Print(map[string]interface{}{"locals": locals}, map[string]struct{}{}, output)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/externalized_json."+terraformoutput.GetFileExtension(output), localsFile, os.ModePerm)
+}
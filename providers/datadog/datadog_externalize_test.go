@@ -0,0 +1,65 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestExternalizeJSON(t *testing.T) {
+	small := `{"title":"small","layout_type":"ordered","widgets":[]}`
+	large := `{"title":"large","layout_type":"ordered","widgets":[` + strings.Repeat(`{"definition":{"type":"note","content":"note"}},`, 50) + `{}]}`
+
+	smallDashboard := terraformutils.NewSimpleResource("abc", "dashboard_json_abc", "datadog_dashboard_json", "datadog", []string{})
+	smallDashboard.Item = map[string]interface{}{"dashboard": small}
+	largeDashboard := terraformutils.NewSimpleResource("def", "dashboard_json_def", "datadog_dashboard_json", "datadog", []string{})
+	largeDashboard.Item = map[string]interface{}{"dashboard": large}
+
+	blobs := externalizeJSON([]terraformutils.Resource{smallDashboard, largeDashboard}, 1024)
+
+	if smallDashboard.Item["dashboard"] != small {
+		t.Errorf("small dashboard should stay inline, got %v", smallDashboard.Item["dashboard"])
+	}
+	name := "datadog_dashboard_json_dashboard_json_def_dashboard"
+	if largeDashboard.Item["dashboard"] != "${file(local."+name+")}" {
+		t.Errorf("large dashboard should reference its file, got %v", largeDashboard.Item["dashboard"])
+	}
+	if len(blobs) != 1 || blobs[name] != large {
+		t.Fatalf("unexpected externalized blobs %v", blobs)
+	}
+
+	path := t.TempDir()
+	if err := writeExternalizedJSON(path, "hcl", blobs); err != nil {
+		t.Fatal(err)
+	}
+	blob, err := ioutil.ReadFile(path + "/json/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(blob) != large {
+		t.Errorf("externalized blob differs from the dashboard")
+	}
+	locals, err := ioutil.ReadFile(path + "/externalized_json.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(locals), name+` = "${path.module}/json/`+name+`.json"`) {
+		t.Errorf("unexpected locals:\n%s", locals)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// serviceFeatures is the product a service belongs to, for the services
+// answering 403 when the product is not enabled on the organization
+var serviceFeatures = map[string]string{
+	"logs_archive":                     "Log Management",
+	"logs_archive_order":               "Log Management",
+	"logs_custom_pipeline":             "Log Management",
+	"logs_index":                       "Log Management",
+	"logs_index_order":                 "Log Management",
+	"logs_integration_pipeline":        "Log Management",
+	"logs_pipeline_order":              "Log Management",
+	"on_call_escalation_policy":        "On-Call",
+	"on_call_schedule":                 "On-Call",
+	"on_call_team_routing_rules":       "On-Call",
+	"rum_application":                  "RUM",
+	"security_monitoring_default_rule": "Cloud SIEM",
+	"security_monitoring_rule":         "Cloud SIEM",
+	"spans_metric":                     "APM",
+	"synthetics":                       "Synthetics",
+	"synthetics_global_variable":       "Synthetics",
+	"synthetics_private_location":      "Synthetics",
+}
+
+// featureGuard skip a service whose product is not enabled on the
+// organization instead of failing the whole export, unless strict
+type featureGuard struct {
+	terraformutils.ServiceGenerator
+	feature  string
+	strict   bool
+	disabled map[string]bool
+}
+
+// InitResources ...
+func (g *featureGuard) InitResources() error {
+	err := g.ServiceGenerator.InitResources()
+	if err == nil || !isForbidden(err) {
+		return err
+	}
+	if g.strict {
+		return fmt.Errorf("%s not enabled on this organization: %v", g.feature, err)
+	}
+	log.Printf("[WARN] %s not enabled on this organization, skipping %s", g.feature, g.GetName())
+	g.disabled[g.feature] = true
+	g.SetResources([]terraformutils.Resource{})
+	return nil
+}
+
+// disabledFeaturesNotice return the notice listing the products skipped
+// because they are not enabled on the organization
+func disabledFeaturesNotice(disabled map[string]bool) string {
+	if len(disabled) == 0 {
+		return ""
+	}
+	features := make([]string, 0, len(disabled))
+	for feature := range disabled {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	return fmt.Sprintf("%d features not enabled on this organization: %s", len(features), strings.Join(features, ", "))
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+type forbiddenGenerator struct {
+	DatadogService
+}
+
+func (g *forbiddenGenerator) InitResources() error {
+	return &apiError{Path: "/api/v2/rum/applications", StatusCode: http.StatusForbidden, Body: `{"errors":["Forbidden"]}`}
+}
+
+func TestFeatureGuardSkipsDisabledFeature(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	disabled := map[string]bool{}
+	service := &forbiddenGenerator{}
+	service.SetName("rum_application")
+	guard := &featureGuard{ServiceGenerator: service, feature: serviceFeatures["rum_application"], disabled: disabled}
+
+	if err := guard.InitResources(); err != nil {
+		t.Fatalf("expected the export to continue, got %v", err)
+	}
+	if len(guard.GetResources()) != 0 {
+		t.Errorf("expected no resources, got %v", guard.GetResources())
+	}
+	if !strings.Contains(output.String(), "RUM not enabled on this organization") {
+		t.Errorf("expected a RUM not enabled notice, got %q", output.String())
+	}
+	if notice := disabledFeaturesNotice(disabled); notice != "1 features not enabled on this organization: RUM" {
+		t.Errorf("unexpected notice %q", notice)
+	}
+
+	guard.strict = true
+	if err := guard.InitResources(); err == nil {
+		t.Error("expected an error in strict mode")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// dependencyEdges return the addresses of the resources referenced by each
+// imported resource, following the provider resource connections
+func dependencyEdges(importedResource map[string][]terraformutils.Resource, connections map[string]map[string][]string) map[string]map[string]bool {
+	edges := map[string]map[string]bool{}
+	for service, connection := range connections {
+		for otherService, pairs := range connection {
+			for i := 0; i+1 < len(pairs); i += 2 {
+				targets := map[string]string{}
+				for _, other := range importedResource[otherService] {
+					value := other.InstanceState.Attributes[pairs[i+1]]
+					if pairs[i+1] == "id" {
+						value = other.InstanceState.ID
+					}
+					targets[value] = other.InstanceInfo.Type + "." + other.ResourceName
+				}
+				for _, r := range importedResource[service] {
+					for _, value := range terraformutils.WalkAndGet(pairs[i], r.Item) {
+						if target, ok := targets[fmt.Sprint(value)]; ok {
+							source := r.InstanceInfo.Type + "." + r.ResourceName
+							if edges[source] == nil {
+								edges[source] = map[string]bool{}
+							}
+							edges[source][target] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return edges
+}
+
+// dependencyGraph return a Graphviz DOT graph of the references between the
+// imported resources, following the provider resource connections
+func dependencyGraph(importedResource map[string][]terraformutils.Resource, connections map[string]map[string][]string) string {
+	var lines []string
+	for source, targets := range dependencyEdges(importedResource, connections) {
+		for target := range targets {
+			lines = append(lines, fmt.Sprintf("  %q -> %q;\n", source, target))
+		}
+	}
+	sort.Strings(lines)
+	return "digraph datadog {\n" + strings.Join(lines, "") + "}\n"
+}
+
+// dependencyCycles return the cycles of the references between the imported
+// resources, each as the addresses of its resources, terraform can't order
+// resources referencing each other
+func dependencyCycles(importedResource map[string][]terraformutils.Resource, connections map[string]map[string][]string) []string {
+	edges := dependencyEdges(importedResource, connections)
+	sortedKeys := func(m map[string]bool) []string {
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	sources := map[string]bool{}
+	for source := range edges {
+		sources[source] = true
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var cycles []string
+	var stack []string
+	var visit func(address string)
+	visit = func(address string) {
+		state[address] = visiting
+		stack = append(stack, address)
+		for _, target := range sortedKeys(edges[address]) {
+			switch state[target] {
+			case visiting:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == target {
+						cycle := append(append([]string{}, stack[i:]...), target)
+						cycles = append(cycles, strings.Join(cycle, " -> "))
+						break
+					}
+				}
+			case 0:
+				visit(target)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[address] = visited
+	}
+	for _, source := range sortedKeys(sources) {
+		if state[source] == 0 {
+			visit(source)
+		}
+	}
+	return cycles
+}
+
+// writeDependencyGraph write the DOT graph of the imported resources to path
+func writeDependencyGraph(path string, importedResource map[string][]terraformutils.Resource, connections map[string]map[string][]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(dependencyGraph(importedResource, connections)), os.ModePerm)
+}
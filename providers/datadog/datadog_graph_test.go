@@ -0,0 +1,64 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	slo := terraformutils.NewSimpleResource("abc", "service_level_objective_abc", "datadog_service_level_objective", "datadog", ServiceLevelObjectiveAllowEmptyValues)
+	slo.Item = map[string]interface{}{"monitor_ids": []interface{}{"12345"}}
+	monitor := terraformutils.NewSimpleResource("12345", "monitor_12345", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	unrelated := terraformutils.NewSimpleResource("67890", "monitor_67890", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+
+	graph := dependencyGraph(map[string][]terraformutils.Resource{
+		"service_level_objective": {slo},
+		"monitor":                 {monitor, unrelated},
+	}, DatadogProvider{}.GetResourceConnections())
+
+	if !strings.Contains(graph, `"datadog_service_level_objective.tfer--service_level_objective_abc" -> "datadog_monitor.tfer--monitor_12345";`) {
+		t.Errorf("expected an edge from the SLO to its monitor, got\n%s", graph)
+	}
+	if strings.Contains(graph, "monitor_67890") {
+		t.Errorf("unexpected edge to an unrelated monitor\n%s", graph)
+	}
+}
+
+func TestDependencyCycles(t *testing.T) {
+	monitor := terraformutils.NewSimpleResource("12345", "monitor_12345", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	monitor.Item = map[string]interface{}{"slo_id": "abc"}
+	slo := terraformutils.NewSimpleResource("abc", "service_level_objective_abc", "datadog_service_level_objective", "datadog", ServiceLevelObjectiveAllowEmptyValues)
+	slo.Item = map[string]interface{}{"monitor_ids": []interface{}{"12345"}}
+	importedResource := map[string][]terraformutils.Resource{
+		"service_level_objective": {slo},
+		"monitor":                 {monitor},
+	}
+
+	if cycles := dependencyCycles(importedResource, DatadogProvider{}.GetResourceConnections()); len(cycles) != 0 {
+		t.Errorf("unexpected cycles %v", cycles)
+	}
+
+	connections := DatadogProvider{}.GetResourceConnections()
+	connections["monitor"] = map[string][]string{"service_level_objective": {"slo_id", "id"}}
+	cycles := dependencyCycles(importedResource, connections)
+	expected := "datadog_monitor.tfer--monitor_12345 -> datadog_service_level_objective.tfer--service_level_objective_abc -> datadog_monitor.tfer--monitor_12345"
+	if len(cycles) != 1 || cycles[0] != expected {
+		t.Errorf("expected the cycle %q, got %v", expected, cycles)
+	}
+}
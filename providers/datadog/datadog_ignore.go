@@ -0,0 +1,72 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// defaultIgnoreFile is read from the working directory when
+// DATADOG_IGNORE_FILE is not set
+const defaultIgnoreFile = ".terraformerignore"
+
+// readIgnoreFile return the glob patterns of the ignore file at filePath, one
+// per line, skipping the blank lines and # comments. A missing file ignores
+// nothing.
+func readIgnoreFile(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := path.Match(line, ""); err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, scanner.Err()
+}
+
+// isIgnored return true if the name, id or resource name of r matches one of
+// the patterns
+func isIgnored(r terraformutils.Resource, patterns []string) bool {
+	for _, pattern := range patterns {
+		for _, value := range []string{r.InstanceState.Attributes["name"], r.InstanceState.ID, r.ResourceName} {
+			if value == "" {
+				continue
+			}
+			if matched, _ := path.Match(pattern, value); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIgnoreFile(t *testing.T) {
+	ignoreFile := t.TempDir() + "/.terraformerignore"
+	content := "# staging monitors are managed by hand\nStaging *\n\n12345\n"
+	if err := ioutil.WriteFile(ignoreFile, []byte(content), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	patterns, err := readIgnoreFile(ignoreFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newMonitor := func(id, name string) terraformutils.Resource {
+		monitor := terraformutils.NewResource(id, "monitor_"+id, "datadog_monitor", "datadog", map[string]string{
+			"id":   id,
+			"name": name,
+		}, MonitorAllowEmptyValues, map[string]interface{}{})
+		monitor.Item = map[string]interface{}{"name": name}
+		return monitor
+	}
+	g := &MonitorGenerator{}
+	g.SetArgs(map[string]interface{}{"ignore-patterns": patterns})
+	g.Resources = []terraformutils.Resource{
+		newMonitor("1", "Staging CPU usage"),
+		newMonitor("12345", "Production disk usage"),
+		newMonitor("2", "Production CPU usage"),
+	}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "2" {
+		t.Errorf("expected only the not ignored monitor, got %v", g.Resources)
+	}
+}
+
+func TestIgnoreFileMissing(t *testing.T) {
+	patterns, err := readIgnoreFile(t.TempDir() + "/.terraformerignore")
+	if err != nil || patterns != nil {
+		t.Errorf("expected a missing ignore file to ignore nothing, got %v %v", patterns, err)
+	}
+}
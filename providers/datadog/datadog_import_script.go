@@ -0,0 +1,51 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// importScript return a shell script importing each resource with terraform import
+func importScript(resources []terraformutils.Resource) string {
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\nset -e\n")
+	for _, r := range resources {
+		fmt.Fprintf(&script, "terraform import %s %s\n", shellQuote(r.InstanceInfo.Type+"."+r.ResourceName), shellQuote(r.InstanceState.ID))
+	}
+	return script.String()
+}
+
+// shellQuote quote value for a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// writeImportScript write import.sh importing the resources exported to path,
+// for users applying the configuration without the generated state
+func writeImportScript(path string, resources []terraformutils.Resource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/import.sh", []byte(importScript(resources)), os.ModePerm)
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// readLastRun return the time of the last successful incremental export
+// stored in path, the zero time on the first run
+func readLastRun(path string) (time.Time, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(content)))
+}
+
+// writeLastRun store in path the time the incremental export started, so the
+// resources modified while it ran are exported by the next one
+func writeLastRun(path string, startedAt time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(startedAt.UTC().Format(time.RFC3339)+"\n"), os.ModePerm)
+}
+
+// incrementalPath return the directory under path receiving the resources
+// modified since the last run, named after the time the export started
+func incrementalPath(path string, startedAt time.Time) string {
+	return strings.TrimSuffix(path, "/") + "/incremental/" + startedAt.UTC().Format("20060102T150405Z") + "/"
+}
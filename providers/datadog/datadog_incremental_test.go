@@ -0,0 +1,158 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIncrementalExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datadog-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "last_run")
+
+	if since, err := readLastRun(path); err != nil || !since.IsZero() {
+		t.Fatalf("expected a full export on the first run, got %v %v", since, err)
+	}
+	lastRun := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := writeLastRun(path, lastRun); err != nil {
+		t.Fatal(err)
+	}
+	since, err := readLastRun(path)
+	if err != nil || !since.Equal(lastRun) {
+		t.Fatalf("expected the last run %v, got %v %v", lastRun, since, err)
+	}
+
+	newDashboard := func(id string, modifiedAt time.Time) datadogV1.DashboardSummaryDashboards {
+		dashboard := datadogV1.DashboardSummaryDashboards{}
+		dashboard.SetId(id)
+		dashboard.SetModifiedAt(modifiedAt)
+		return dashboard
+	}
+	g := &DashboardGenerator{}
+	g.SetArgs(map[string]interface{}{"modified-since": since})
+	resources := g.createResources([]datadogV1.DashboardSummaryDashboards{
+		newDashboard("abc-def-ghi", lastRun.Add(time.Hour)),
+		newDashboard("jkl-mno-pqr", lastRun.Add(-time.Hour)),
+	})
+	if len(resources) != 1 || resources[0].InstanceState.ID != "abc-def-ghi" {
+		t.Errorf("expected only the dashboard modified since the last run, got %v", resources)
+	}
+
+	startedAt := lastRun.Add(24 * time.Hour)
+	if err := writeLastRun(path, startedAt); err != nil {
+		t.Fatal(err)
+	}
+	if since, err := readLastRun(path); err != nil || !since.Equal(startedAt) {
+		t.Errorf("expected the timestamp file to be updated to %v, got %v %v", startedAt, since, err)
+	}
+}
+
+func TestIncrementalLastRunStoredOnOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datadog-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "last_run")
+	startedAt := time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC)
+	p := &DatadogProvider{lastRunPath: path, startedAt: startedAt}
+	p.OrderServices([]string{"dashboard", "monitor"})
+
+	// the monitors failed to import
+	if err := p.PostImportHook(map[string][]terraformutils.Resource{"dashboard": nil}); err != nil {
+		t.Fatal(err)
+	}
+	if since, err := readLastRun(path); err != nil || !since.IsZero() {
+		t.Fatalf("expected the last run to be stored once the files are written, got %v %v", since, err)
+	}
+	if err := p.OutputHook(dir); err != nil {
+		t.Fatal(err)
+	}
+	if since, err := readLastRun(path); err != nil || !since.IsZero() {
+		t.Fatalf("expected the last run to be kept when a service failed, got %v %v", since, err)
+	}
+
+	if err := p.PostImportHook(map[string][]terraformutils.Resource{"dashboard": nil, "monitor": nil}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.OutputHook(dir); err != nil {
+		t.Fatal(err)
+	}
+	if since, err := readLastRun(path); err != nil || !since.Equal(startedAt) {
+		t.Errorf("expected the last run %v, got %v %v", startedAt, since, err)
+	}
+}
+
+func TestIncrementalSplitPath(t *testing.T) {
+	startedAt := time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC)
+	resources := []terraformutils.Resource{{}}
+	p := &DatadogProvider{startedAt: startedAt}
+	if groups := p.SplitPath("generated/datadog/dashboard/", resources); len(groups["generated/datadog/dashboard/"]) != 1 {
+		t.Errorf("expected the first run to write the full export in the service path, got %v", groups)
+	}
+	p.modifiedSince = startedAt.Add(-24 * time.Hour)
+	expected := "generated/datadog/dashboard/incremental/20210302T120000Z/"
+	if groups := p.SplitPath("generated/datadog/dashboard/", resources); len(groups) != 1 || len(groups[expected]) != 1 {
+		t.Errorf("expected the modified resources to be written in %s, got %v", expected, groups)
+	}
+}
+
+func TestIncrementalUsersSortedByModification(t *testing.T) {
+	requests := 0
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/v2/users" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if query := r.URL.Query(); query.Get("sort") != "modified_at" || query.Get("sort_dir") != "desc" {
+			t.Errorf("expected the users sorted from the most recently modified, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "user-1", "type": "users", "attributes": {"handle": "new@example.com", "modified_at": "2021-03-02T10:00:00.000000+00:00"},
+				"relationships": {"roles": {"data": [{"id": "role-1", "type": "roles"}]}}},
+			{"id": "user-2", "type": "users", "attributes": {"handle": "old@example.com", "modified_at": "2021-02-01T10:00:00.000000+00:00"},
+				"relationships": {"roles": {"data": [{"id": "role-1", "type": "roles"}]}}}
+		]}`))
+	}))
+
+	g := &UserGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+		"modified-since":  time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "user-1" {
+		t.Errorf("expected only the user modified since the last run, got %v", g.Resources)
+	}
+	if requests != 1 {
+		t.Errorf("expected the paging to stop at the first user not modified, got %d requests", requests)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+// managedTagTypes list the resource types with free form tags, checked
+// rather than the item as empty tags are dropped from it
+var managedTagTypes = map[string]bool{
+	"datadog_monitor":                     true,
+	"datadog_security_monitoring_rule":    true,
+	"datadog_service_level_objective":     true,
+	"datadog_synthetics_private_location": true,
+	"datadog_synthetics_test":             true,
+}
+
+// addManagedTag append tag to the tags of item unless already there
+func addManagedTag(item map[string]interface{}, resourceType, tag string) {
+	if !managedTagTypes[resourceType] {
+		return
+	}
+	tags, _ := item["tags"].([]interface{})
+	for _, existing := range tags {
+		if existing == tag {
+			return
+		}
+	}
+	item["tags"] = append(tags, tag)
+}
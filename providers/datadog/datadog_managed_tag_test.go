@@ -0,0 +1,53 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestManagedTag(t *testing.T) {
+	tagged := terraformutils.NewSimpleResource("123", "monitor_123", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	tagged.Item = map[string]interface{}{"name": "foo", "tags": []interface{}{"env:prod"}}
+	alreadyManaged := terraformutils.NewSimpleResource("456", "monitor_456", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	alreadyManaged.Item = map[string]interface{}{"name": "bar", "tags": []interface{}{"terraform:true", "env:prod"}}
+	untagged := terraformutils.NewSimpleResource("789", "monitor_789", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	untagged.Item = map[string]interface{}{"name": "baz"}
+	dashboard := terraformutils.NewSimpleResource("abc", "dashboard_abc", "datadog_dashboard", "datadog", DashboardAllowEmptyValues)
+	dashboard.Item = map[string]interface{}{"title": "shop"}
+
+	g := &MonitorGenerator{}
+	g.Args = map[string]interface{}{"managed-tag": "terraform:true"}
+	g.Resources = []terraformutils.Resource{tagged, alreadyManaged, untagged, dashboard}
+	for run := 0; run < 2; run++ {
+		if err := g.DatadogService.PostConvertHook(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, expected := range []interface{}{
+		[]interface{}{"env:prod", "terraform:true"},
+		[]interface{}{"terraform:true", "env:prod"},
+		[]interface{}{"terraform:true"},
+		nil,
+	} {
+		if tags := g.Resources[i].Item["tags"]; !reflect.DeepEqual(tags, expected) {
+			t.Errorf("expected %s tags %v, got %v", g.Resources[i].ResourceName, expected, tags)
+		}
+	}
+}
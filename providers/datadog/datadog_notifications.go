@@ -0,0 +1,67 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// notificationHandle match a PagerDuty or Opsgenie handle, without the
+// punctuation ending the sentence it is written in
+var notificationHandle = regexp.MustCompile(`@(pagerduty|opsgenie)-([0-9A-Za-z_.-]*[0-9A-Za-z_])`)
+
+// notificationServices is the resource type and attribute naming the services
+// of the notification integrations mentioned in monitor messages
+var notificationServices = map[string][2]string{
+	"pagerduty": {"datadog_integration_pagerduty_service_object", "service_name"},
+	"opsgenie":  {"datadog_integration_opsgenie_service_object", "name"},
+}
+
+// unmanagedNotificationHandles return the PagerDuty and Opsgenie handles
+// notified by the imported monitors whose service is not exported, reported
+// with DATADOG_USE_DATA_SOURCES. The datadog provider has no data source for
+// these services, so no data source is emitted: the handles are left as is
+// in the messages and reported so the services can be imported.
+func unmanagedNotificationHandles(importedResource map[string][]terraformutils.Resource) []string {
+	managed := map[string]bool{}
+	for _, resources := range importedResource {
+		for _, r := range resources {
+			for integration, service := range notificationServices {
+				if r.InstanceInfo.Type == service[0] {
+					managed["@"+integration+"-"+r.InstanceState.Attributes[service[1]]] = true
+				}
+			}
+		}
+	}
+
+	unmanaged := map[string]bool{}
+	for _, r := range importedResource["monitor"] {
+		for _, match := range notificationHandle.FindAllString(r.InstanceState.Attributes["message"], -1) {
+			if !managed[match] {
+				unmanaged[fmt.Sprintf("%s notified by %s.%s", match, r.InstanceInfo.Type, r.ResourceName)] = true
+			}
+		}
+	}
+	handles := make([]string, 0, len(unmanaged))
+	for handle := range unmanaged {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	return handles
+}
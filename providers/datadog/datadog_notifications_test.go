@@ -0,0 +1,46 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestUnmanagedNotificationHandles(t *testing.T) {
+	monitor := terraformutils.NewResource("12345", "monitor_12345", "datadog_monitor", "datadog", map[string]string{
+		"id":      "12345",
+		"message": "CPU is high @pagerduty-payments-oncall @opsgenie-core {{#is_recovery}}@pagerduty-payments-oncall{{/is_recovery}} Escalate to @pagerduty-core-infra.",
+	}, MonitorAllowEmptyValues, map[string]interface{}{})
+	opsgenie := terraformutils.NewResource("uuid", "opsgenie_core", "datadog_integration_opsgenie_service_object", "datadog", map[string]string{
+		"id":   "uuid",
+		"name": "core",
+	}, []string{}, map[string]interface{}{})
+
+	handles := unmanagedNotificationHandles(map[string][]terraformutils.Resource{
+		"monitor":                      {monitor},
+		"integration_opsgenie_service": {opsgenie},
+	})
+
+	expected := []string{
+		"@pagerduty-core-infra notified by datadog_monitor.tfer--monitor_12345",
+		"@pagerduty-payments-oncall notified by datadog_monitor.tfer--monitor_12345",
+	}
+	if !reflect.DeepEqual(handles, expected) {
+		t.Errorf("expected %v, got %v", expected, handles)
+	}
+}
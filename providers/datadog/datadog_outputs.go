@@ -0,0 +1,61 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// idOutputs return an output exposing the id of each resource, keyed by its
+// block name without the tfer-- prefix, for downstream modules
+func idOutputs(resources []terraformutils.Resource) (map[string]interface{}, error) {
+	outputs := map[string]interface{}{}
+	addresses := map[string]string{}
+	for _, r := range resources {
+		name := strings.TrimPrefix(r.ResourceName, "tfer--") + "_id"
+		address := r.InstanceInfo.Type + "." + r.ResourceName
+		if previous, exist := addresses[name]; exist {
+			return nil, fmt.Errorf("output %s would expose both %s and %s", name, previous, address)
+		}
+		addresses[name] = address
+		outputs[name] = map[string]interface{}{
+			"value": "${" + address + ".id}",
+		}
+	}
+	return outputs, nil
+}
+
+// writeIDOutputs write id_outputs.tf exposing the id of each resource exported to path.
+// outputs.tf is left to terraformer which writes it for --connect.
+func writeIDOutputs(path, output string, resources []terraformutils.Resource) error {
+	outputs, err := idOutputs(resources)
+	if err != nil || len(outputs) == 0 {
+		return err
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+	outputsFile, err := terraformutils.Print(map[string]interface{}{"output": outputs}, map[string]struct{}{}, output)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/id_outputs."+terraformoutput.GetFileExtension(output), outputsFile, os.ModePerm)
+}
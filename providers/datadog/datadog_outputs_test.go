@@ -0,0 +1,41 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestWriteIDOutputs(t *testing.T) {
+	monitor := terraformutils.NewSimpleResource("12345", "monitor_12345", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+
+	path := t.TempDir()
+	if err := writeIDOutputs(path, "hcl", []terraformutils.Resource{monitor}); err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := ioutil.ReadFile(path + "/id_outputs.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`output "monitor_12345_id"`, `"${datadog_monitor.tfer--monitor_12345.id}"`} {
+		if !strings.Contains(string(outputs), expected) {
+			t.Errorf("expected %s in outputs:\n%s", expected, outputs)
+		}
+	}
+}
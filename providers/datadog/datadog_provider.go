@@ -18,8 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
@@ -32,12 +37,136 @@ type DatadogProvider struct { //nolint
 	apiKey          string
 	appKey          string
 	apiURL          string
+	providerAlias   string
+	tfvarsFields    []tfvarsField
+	jsonThreshold   int
+	syntheticsJobs  int
+	httpRetries     int
+	ignoreChanges   map[string][]string
+	resourceIndex   bool
+	ownerHandle     string
+	monitorQuery    string
+	managedTag      string
+	monitorJSON     bool
+	dashboardJSON   bool
+	idOutputs       bool
+	validateQueries bool
+	groupByTeam     bool
+	groupByEnv      bool
+	serviceOrder    []string
+	secretsFile     bool
+	secretsManifest bool
+	inferGroups     bool
+	schemas         map[string]schemaBlock
+	sample          int
+	importScript    bool
+	emitStateV4     bool
+	sloDashboards   bool
+	ignorePatterns  []string
+	tagPolicies     bool
+	dedupeMonitors  bool
+	target          *terraformutils.ResourceFilter
+	strict          bool
+	useDataSources  bool
+	disabled        map[string]bool
+	graphPath       string
+	outputZip       string
+	validateAcyclic bool
+	archiveOrder    bool
+	providerVars    bool
+	keyOwners       map[string]string
+	restricted      map[string]bool
+	validateAWS     bool
+	migrateAWS      bool
+	validateLambdas bool
+	annotate        bool
+	fingerprints    bool
+	lastRunPath     string
+	modifiedSince   time.Time
+	startedAt       time.Time
+	requested       []string
+	importComplete  bool
 	authV1          context.Context
 	authV2          context.Context
 	datadogClientV1 *datadogV1.APIClient
 	datadogClientV2 *datadogV2.APIClient
 }
 
+// boolOptions map the env params of the boolean options to their field,
+// a new option only needs its line here
+func (p *DatadogProvider) boolOptions() []struct {
+	env   string
+	field *bool
+} {
+	return []struct {
+		env   string
+		field *bool
+	}{
+		{"DATADOG_WRITE_RESOURCE_INDEX", &p.resourceIndex},
+		{"DATADOG_PROVIDER_FROM_VARS", &p.providerVars},
+		{"DATADOG_VALIDATE_LAMBDA_FORWARDERS", &p.validateLambdas},
+		{"DATADOG_INFER_ARCHIVE_ORDER", &p.archiveOrder},
+		{"DATADOG_VALIDATE_ACYCLIC", &p.validateAcyclic},
+		{"DATADOG_VALIDATE_QUERIES", &p.validateQueries},
+		{"DATADOG_GROUP_BY_TEAM", &p.groupByTeam},
+		{"DATADOG_VALIDATE_TAG_POLICIES", &p.tagPolicies},
+		{"DATADOG_EMIT_IMPORT_SCRIPT", &p.importScript},
+		{"DATADOG_EMIT_STATE_V4", &p.emitStateV4},
+		{"DATADOG_GENERATE_SLO_DASHBOARDS", &p.sloDashboards},
+		{"DATADOG_SECRETS_FILE", &p.secretsFile},
+		{"DATADOG_SECRETS_MANIFEST", &p.secretsManifest},
+		{"DATADOG_INFER_GROUPS", &p.inferGroups},
+		{"DATADOG_WORKSPACE_PER_ENV", &p.groupByEnv},
+		{"DATADOG_VALIDATE_AWS_ACCOUNTS", &p.validateAWS},
+		{"DATADOG_MIGRATE_AWS_NAMESPACES", &p.migrateAWS},
+		{"DATADOG_ANNOTATE_PROVIDER_VERSIONS", &p.annotate},
+		{"DATADOG_SECRET_FINGERPRINTS", &p.fingerprints},
+		{"DATADOG_STRICT", &p.strict},
+		{"DATADOG_USE_DATA_SOURCES", &p.useDataSources},
+		{"DATADOG_DEDUPE_MONITORS", &p.dedupeMonitors},
+		{"DATADOG_EMIT_ID_OUTPUTS", &p.idOutputs},
+	}
+}
+
+// intOptions map the env params of the integer options to their field
+func (p *DatadogProvider) intOptions() []struct {
+	env   string
+	field *int
+} {
+	return []struct {
+		env   string
+		field *int
+	}{
+		{"DATADOG_EXTERNALIZE_JSON_THRESHOLD", &p.jsonThreshold},
+		{"DATADOG_SYNTHETICS_CONCURRENCY", &p.syntheticsJobs},
+		{"DATADOG_HTTP_RETRIES", &p.httpRetries},
+		{"DATADOG_SAMPLE", &p.sample},
+	}
+}
+
+// parseOptions set the boolean and integer options from their env params
+func (p *DatadogProvider) parseOptions() error {
+	for _, option := range p.boolOptions() {
+		if v := os.Getenv(option.env); v != "" {
+			value, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf(`invalid %s : %v`, option.env, err)
+			}
+			*option.field = value
+		}
+	}
+	for _, option := range p.intOptions() {
+		if v := os.Getenv(option.env); v != "" {
+			value, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf(`invalid %s : %v`, option.env, err)
+			}
+			*option.field = value
+		}
+	}
+	return nil
+}
+
 // Init check env params and initialize API Client
 func (p *DatadogProvider) Init(args []string) error {
 	if args[0] != "" {
@@ -66,6 +195,114 @@ func (p *DatadogProvider) Init(args []string) error {
 		p.apiURL = v
 	}
 
+	if v := os.Getenv("DATADOG_PROVIDER_ALIAS_PER_SITE"); v != "" {
+		aliasPerSite, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_PROVIDER_ALIAS_PER_SITE : %v`, err)
+		}
+		if aliasPerSite {
+			p.providerAlias = siteAlias(p.apiURL)
+		}
+	}
+
+	if v := os.Getenv("DATADOG_INCREMENTAL_STATE"); v != "" {
+		modifiedSince, err := readLastRun(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_INCREMENTAL_STATE : %v`, err)
+		}
+		p.lastRunPath = v
+		p.modifiedSince = modifiedSince
+		p.startedAt = time.Now()
+	}
+
+	if v := os.Getenv("DATADOG_PROVIDER_SCHEMA"); v != "" {
+		schemas, err := loadProviderSchema(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_PROVIDER_SCHEMA : %v`, err)
+		}
+		p.schemas = schemas
+	}
+
+	ignoreFile := defaultIgnoreFile
+	if v := os.Getenv("DATADOG_IGNORE_FILE"); v != "" {
+		ignoreFile = v
+	}
+	ignorePatterns, err := readIgnoreFile(ignoreFile)
+	if err != nil {
+		return fmt.Errorf(`invalid %s : %v`, ignoreFile, err)
+	}
+	p.ignorePatterns = ignorePatterns
+
+	if v := os.Getenv("DATADOG_TFVARS_FIELDS"); v != "" {
+		tfvarsFields, err := parseTfvarsFields(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_TFVARS_FIELDS : %v`, err)
+		}
+		p.tfvarsFields = tfvarsFields
+	}
+
+	if v := os.Getenv("DATADOG_IGNORE_CHANGES"); v != "" {
+		ignoreChanges, err := parseIgnoreChanges(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_IGNORE_CHANGES : %v`, err)
+		}
+		p.ignoreChanges = ignoreChanges
+	}
+
+	if err := p.parseOptions(); err != nil {
+		return err
+	}
+
+	p.monitorQuery = os.Getenv("DATADOG_MONITOR_SEARCH_QUERY")
+	p.managedTag = os.Getenv("DATADOG_ADD_MANAGED_TAG")
+	p.graphPath = os.Getenv("DATADOG_EMIT_GRAPH")
+	p.outputZip = os.Getenv("DATADOG_OUTPUT_ZIP")
+
+	if v := os.Getenv("DATADOG_TARGET_URL"); v != "" {
+		target, err := parseTargetURL(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_TARGET_URL : %v`, err)
+		}
+		p.target = target
+	}
+
+	switch v := os.Getenv("DATADOG_MONITOR_FORMAT"); v {
+	case "", "typed":
+	case "json":
+		p.monitorJSON = true
+	default:
+		return fmt.Errorf(`invalid DATADOG_MONITOR_FORMAT : %q is neither typed nor json`, v)
+	}
+
+	switch v := os.Getenv("DATADOG_DASHBOARD_FORMAT"); v {
+	case "", "typed":
+	case "json":
+		p.dashboardJSON = true
+	default:
+		return fmt.Errorf(`invalid DATADOG_DASHBOARD_FORMAT : %q is neither typed nor json`, v)
+	}
+
+	if v := os.Getenv("DATADOG_SERVICE_ORDER"); v != "" {
+		serviceOrder, err := parseServiceOrder(v, p.GetSupportedService())
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_SERVICE_ORDER : %v`, err)
+		}
+		p.serviceOrder = serviceOrder
+	}
+
+	p.disabled = map[string]bool{}
+	p.keyOwners = map[string]string{}
+	p.restricted = map[string]bool{}
+
+	// Record or replay the API answers, the http client is shared by the V1 and V2 clients
+	httpClient, err := newHTTPClient(os.Getenv("DATADOG_RECORD_MODE"), os.Getenv("DATADOG_CASSETTE"), []string{p.apiKey, p.appKey})
+	if err != nil {
+		return fmt.Errorf(`invalid DATADOG_RECORD_MODE : %v`, err)
+	}
+	if p.httpRetries > 0 {
+		httpClient.Transport = newRetryTransport(httpClient.Transport, p.httpRetries, time.Second)
+	}
+
 	// Initialize the Datadog V1 API client
 	authV1 := context.WithValue(
 		context.Background(),
@@ -95,6 +332,7 @@ func (p *DatadogProvider) Init(args []string) error {
 		})
 	}
 	configV1 := datadogV1.NewConfiguration()
+	configV1.HTTPClient = httpClient
 
 	// Enable unstable operations
 	configV1.SetUnstableOperationEnabled("GetLogsIndex", true)
@@ -131,6 +369,12 @@ func (p *DatadogProvider) Init(args []string) error {
 		})
 	}
 	configV2 := datadogV2.NewConfiguration()
+	configV2.HTTPClient = httpClient
+
+	// Enable unstable operations
+	configV2.SetUnstableOperationEnabled("GetLogsMetric", true)
+	configV2.SetUnstableOperationEnabled("ListLogsMetrics", true)
+
 	datadogClientV2 := datadogV2.NewAPIClient(configV2)
 
 	p.authV1 = authV1
@@ -138,6 +382,20 @@ func (p *DatadogProvider) Init(args []string) error {
 	p.datadogClientV1 = datadogClientV1
 	p.datadogClientV2 = datadogClientV2
 
+	if v := os.Getenv("DATADOG_OWNED_BY_CURRENT_USER"); v != "" {
+		ownedByCurrentUser, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf(`invalid DATADOG_OWNED_BY_CURRENT_USER : %v`, err)
+		}
+		if ownedByCurrentUser {
+			ownerHandle, err := currentUserHandle(datadogClientV2, authV2)
+			if err != nil {
+				return fmt.Errorf(`unable to get the current user : %v`, err)
+			}
+			p.ownerHandle = ownerHandle
+		}
+	}
+
 	return nil
 }
 
@@ -162,60 +420,469 @@ func (p *DatadogProvider) InitService(serviceName string, verbose bool) error {
 		return errors.New(p.GetName() + ": " + serviceName + " not supported service")
 	}
 	p.Service = p.GetSupportedService()[serviceName]
+	if serviceName == "monitor" && p.monitorJSON {
+		p.Service = &MonitorJSONGenerator{}
+	}
+	if serviceName == "dashboard" && p.dashboardJSON {
+		p.Service = &DashboardJSONGenerator{}
+	}
 	p.Service.SetName(serviceName)
 	p.Service.SetVerbose(verbose)
 	p.Service.SetProviderName(p.GetName())
 	p.Service.SetArgs(map[string]interface{}{
-		"api-key":         p.apiKey,
-		"app-key":         p.appKey,
-		"api-url":         p.apiURL,
-		"authV1":          p.authV1,
-		"authV2":          p.authV2,
-		"datadogClientV1": p.datadogClientV1,
-		"datadogClientV2": p.datadogClientV2,
+		"api-key":                    p.apiKey,
+		"app-key":                    p.appKey,
+		"api-url":                    p.apiURL,
+		"provider-alias":             p.providerAlias,
+		"ignore-changes":             p.ignoreChanges,
+		"owner-handle":               p.ownerHandle,
+		"monitor-search-query":       p.monitorQuery,
+		"managed-tag":                p.managedTag,
+		"target":                     p.target,
+		"validate-aws-accounts":      p.validateAWS,
+		"migrate-aws-namespaces":     p.migrateAWS,
+		"annotate-provider-versions": p.annotate,
+		"secret-fingerprints":        p.fingerprints,
+		"ignore-patterns":            p.ignorePatterns,
+		"synthetics-concurrency":     p.syntheticsJobs,
+		"modified-since":             p.modifiedSince,
+		"application-key-owners":     p.keyOwners,
+		"restriction-targets":        p.restricted,
+		"authV1":                     p.authV1,
+		"authV2":                     p.authV2,
+		"datadogClientV1":            p.datadogClientV1,
+		"datadogClientV2":            p.datadogClientV2,
 	})
+	if feature, ok := serviceFeatures[serviceName]; ok {
+		p.Service = &featureGuard{
+			ServiceGenerator: p.Service,
+			feature:          feature,
+			strict:           p.strict,
+			disabled:         p.disabled,
+		}
+	}
+	if p.sample > 0 {
+		p.Service = &sampler{ServiceGenerator: p.Service, size: p.sample}
+	}
 	return nil
 }
 
 // GetSupportedService return map of support service for Datadog
 func (p *DatadogProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
 	return map[string]terraformutils.ServiceGenerator{
-		"dashboard_list":                   &DashboardListGenerator{},
-		"dashboard":                        &DashboardGenerator{},
-		"downtime":                         &DowntimeGenerator{},
-		"logs_archive":                     &LogsArchiveGenerator{},
-		"logs_archive_order":               &LogsArchiveOrderGenerator{},
-		"logs_custom_pipeline":             &LogsCustomPipelineGenerator{},
-		"logs_index":                       &LogsIndexGenerator{},
-		"logs_index_order":                 &LogsIndexOrderGenerator{},
-		"logs_integration_pipeline":        &LogsIntegrationPipelineGenerator{},
-		"logs_pipeline_order":              &LogsPipelineOrderGenerator{},
-		"integration_aws":                  &IntegrationAWSGenerator{},
-		"integration_aws_lambda_arn":       &IntegrationAWSLambdaARNGenerator{},
-		"integration_aws_log_collection":   &IntegrationAWSLogCollectionGenerator{},
-		"integration_azure":                &IntegrationAzureGenerator{},
-		"integration_gcp":                  &IntegrationGCPGenerator{},
-		"metric_metadata":                  &MetricMetadataGenerator{},
-		"monitor":                          &MonitorGenerator{},
-		"screenboard":                      &ScreenboardGenerator{},
-		"security_monitoring_default_rule": &SecurityMonitoringDefaultRuleGenerator{},
-		"security_monitoring_rule":         &SecurityMonitoringRuleGenerator{},
-		"service_level_objective":          &ServiceLevelObjectiveGenerator{},
-		"synthetics":                       &SyntheticsGenerator{},
-		"synthetics_global_variable":       &SyntheticsGlobalVariableGenerator{},
-		"synthetics_private_location":      &SyntheticsPrivateLocationGenerator{},
-		"timeboard":                        &TimeboardGenerator{},
-		"user":                             &UserGenerator{},
-		"role":                             &RoleGenerator{},
+		"api_key":                              &APIKeyGenerator{},
+		"application_key":                      &ApplicationKeyGenerator{},
+		"authn_mapping":                        &AuthNMappingGenerator{},
+		"dashboard_list":                       &DashboardListGenerator{},
+		"dashboard":                            &DashboardGenerator{},
+		"dashboard_json":                       &DashboardJSONGenerator{},
+		"downtime":                             &DowntimeGenerator{},
+		"logs_archive":                         &LogsArchiveGenerator{},
+		"logs_archive_order":                   &LogsArchiveOrderGenerator{},
+		"logs_custom_pipeline":                 &LogsCustomPipelineGenerator{},
+		"logs_index":                           &LogsIndexGenerator{},
+		"logs_index_order":                     &LogsIndexOrderGenerator{},
+		"logs_integration_pipeline":            &LogsIntegrationPipelineGenerator{},
+		"logs_metric":                          &LogsMetricGenerator{},
+		"logs_pipeline_order":                  &LogsPipelineOrderGenerator{},
+		"integration_aws":                      &IntegrationAWSGenerator{},
+		"integration_aws_account":              &IntegrationAWSAccountGenerator{},
+		"integration_aws_lambda_arn":           &IntegrationAWSLambdaARNGenerator{},
+		"integration_aws_log_collection":       &IntegrationAWSLogCollectionGenerator{},
+		"integration_azure":                    &IntegrationAzureGenerator{},
+		"integration_cloudflare_account":       &IntegrationCloudflareAccountGenerator{},
+		"integration_confluent_account":        &IntegrationConfluentAccountGenerator{},
+		"integration_confluent_resource":       &IntegrationConfluentResourceGenerator{},
+		"integration_fastly_account":           &IntegrationFastlyAccountGenerator{},
+		"integration_fastly_service":           &IntegrationFastlyServiceGenerator{},
+		"integration_gcp":                      &IntegrationGCPGenerator{},
+		"integration_gcp_sts":                  &IntegrationGCPStsGenerator{},
+		"integration_pagerduty":                &IntegrationPagerdutyGenerator{},
+		"integration_pagerduty_service_object": &IntegrationPagerdutyServiceObjectGenerator{},
+		"integration_slack_channel":            &IntegrationSlackChannelGenerator{},
+		"ip_allowlist":                         &IPAllowlistGenerator{},
+		"metric_metadata":                      &MetricMetadataGenerator{},
+		"metric_tag_configuration":             &MetricTagConfigurationGenerator{},
+		"monitor":                              &MonitorGenerator{},
+		"monitor_config_policy":                &MonitorConfigPolicyGenerator{},
+		"monitor_json":                         &MonitorJSONGenerator{},
+		"on_call_escalation_policy":            &OnCallEscalationPolicyGenerator{},
+		"on_call_schedule":                     &OnCallScheduleGenerator{},
+		"on_call_team_routing_rules":           &OnCallTeamRoutingRulesGenerator{},
+		"organization_settings":                &OrganizationSettingsGenerator{},
+		"powerpack":                            &PowerpackGenerator{},
+		"screenboard":                          &ScreenboardGenerator{},
+		"security_monitoring_default_rule":     &SecurityMonitoringDefaultRuleGenerator{},
+		"security_monitoring_rule":             &SecurityMonitoringRuleGenerator{},
+		"security_monitoring_suppression":      &SecurityMonitoringSuppressionGenerator{},
+		"sensitive_data_scanner_group":         &SensitiveDataScannerGroupGenerator{},
+		"sensitive_data_scanner_rule":          &SensitiveDataScannerRuleGenerator{},
+		"service_account":                      &ServiceAccountGenerator{},
+		"service_definition_yaml":              &ServiceDefinitionGenerator{},
+		"service_level_objective":              &ServiceLevelObjectiveGenerator{},
+		"slo_correction":                       &SLOCorrectionGenerator{},
+		"spans_metric":                         &SpansMetricGenerator{},
+		"synthetics":                           &SyntheticsGenerator{},
+		"synthetics_global_variable":           &SyntheticsGlobalVariableGenerator{},
+		"synthetics_private_location":          &SyntheticsPrivateLocationGenerator{},
+		"team":                                 &TeamGenerator{},
+		"team_membership":                      &TeamMembershipGenerator{},
+		"team_permission_setting":              &TeamPermissionSettingGenerator{},
+		"timeboard":                            &TimeboardGenerator{},
+		"user":                                 &UserGenerator{},
+		"webhook":                              &WebhookGenerator{},
+		"webhook_custom_variable":              &WebhookCustomVariableGenerator{},
+		"restriction_policy":                   &RestrictionPolicyGenerator{},
+		"role":                                 &RoleGenerator{},
+		"rum_application":                      &RUMApplicationGenerator{},
 	}
 }
 
 // GetResourceConnections return map of resource connections for Datadog
 func (DatadogProvider) GetResourceConnections() map[string]map[string][]string {
-	return map[string]map[string][]string{}
+	return map[string]map[string][]string{
+		"authn_mapping": {
+			"role": []string{"role", "id"},
+		},
+		"dashboard": {
+			"powerpack": []string{
+				"widget.powerpack_definition.powerpack_id", "id",
+				"widget.group_definition.widget.powerpack_definition.powerpack_id", "id",
+			},
+			"role": []string{"restricted_roles", "id"},
+		},
+		"integration_confluent_resource": {
+			"integration_confluent_account": []string{"account_id", "id"},
+		},
+		"integration_fastly_service": {
+			"integration_fastly_account": []string{"account_id", "id"},
+		},
+		"monitor": {
+			"role": []string{"restricted_roles", "id"},
+			"team": []string{"restricted_roles", "id"},
+		},
+		"on_call_escalation_policy": {
+			"on_call_schedule": []string{"step.target.schedule", "id"},
+			"team":             []string{"step.target.team", "id", "teams", "id"},
+			"user":             []string{"step.target.user", "id"},
+		},
+		"on_call_team_routing_rules": {
+			"on_call_escalation_policy": []string{"rule.escalation_policy", "id"},
+			"team":                      []string{"id", "id"},
+		},
+		"service_level_objective": {
+			"monitor": []string{"monitor_ids", "id"},
+		},
+		"sensitive_data_scanner_rule": {
+			"sensitive_data_scanner_group": []string{"group_id", "id"},
+		},
+		"service_account": {
+			"role": []string{"roles", "id"},
+		},
+		"slo_correction": {
+			"service_level_objective": []string{"slo_id", "id"},
+		},
+		"synthetics": {
+			"synthetics_private_location": []string{"locations", "id"},
+		},
+		"team_membership": {
+			"team": []string{"team_id", "id"},
+			"user": []string{"user_id", "id"},
+		},
+		"team_permission_setting": {
+			"team": []string{"team_id", "id"},
+		},
+	}
+}
+
+// PostImportHook reconcile and validate the resources of all imported services
+func (p *DatadogProvider) PostImportHook(importedResource map[string][]terraformutils.Resource) error {
+	reconcileAWSLogCollection(importedResource)
+	if p.validateLambdas {
+		for _, warning := range lambdaForwarderWarnings(importedResource) {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+	if p.archiveOrder {
+		inferLogsArchiveOrder(importedResource)
+	}
+	if err := mapMetricComputes(importedResource); err != nil {
+		return err
+	}
+	if p.validateQueries {
+		for _, warning := range queryWarnings(importedResource) {
+			log.Printf("[WARN] malformed query %s", warning)
+		}
+	}
+	for _, warning := range monitorWarnings(importedResource) {
+		log.Printf("[WARN] %s", warning)
+	}
+	if p.tagPolicies {
+		for _, warning := range tagPolicyWarnings(importedResource) {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+	if p.schemas != nil {
+		for _, warning := range schemaWarnings(importedResource, p.schemas) {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+	for _, warning := range privateLocationWarnings(importedResource) {
+		log.Printf("[WARN] %s", warning)
+	}
+	if p.useDataSources {
+		for _, handle := range unmanagedNotificationHandles(importedResource) {
+			log.Printf("[WARN] unmanaged notification integration %s", handle)
+		}
+	}
+	if notice := disabledFeaturesNotice(p.disabled); notice != "" {
+		log.Printf("[WARN] %s", notice)
+	}
+	if p.graphPath != "" {
+		if err := writeDependencyGraph(p.graphPath, importedResource, p.GetResourceConnections()); err != nil {
+			return err
+		}
+	}
+	if err := validateUniqueImportIDs(importedResource); err != nil {
+		return err
+	}
+	if p.validateAcyclic {
+		if cycles := dependencyCycles(importedResource, p.GetResourceConnections()); len(cycles) > 0 {
+			return fmt.Errorf("cyclic resource connections found:\n%s", strings.Join(cycles, "\n"))
+		}
+	}
+	// the services failing to import are skipped, the next incremental run
+	// must then export what they missed
+	p.importComplete = true
+	for _, service := range p.requested {
+		if _, ok := importedResource[service]; !ok {
+			p.importComplete = false
+		}
+	}
+	return nil
+}
+
+// PrintHook apply the export options writing extra files next to the resources of a service
+func (p *DatadogProvider) PrintHook(path, output string, resources []terraformutils.Resource) ([]terraformutils.Resource, error) {
+	if p.jsonThreshold > 0 {
+		if err := writeExternalizedJSON(path, output, externalizeJSON(resources, p.jsonThreshold)); err != nil {
+			return nil, err
+		}
+	}
+	if len(p.tfvarsFields) > 0 {
+		if err := writeTfvarsSeed(path, output, extractTfvars(resources, p.tfvarsFields)); err != nil {
+			return nil, err
+		}
+	}
+	if p.inferGroups {
+		if err := writeMonitorTagGroups(path, inferMonitorTagGroups(resources)); err != nil {
+			return nil, err
+		}
+	}
+	secretResources := resources
+	if !p.secretsFile {
+		secretResources = nil
+		for _, r := range resources {
+			if redactedTypes[r.InstanceInfo.Type] {
+				secretResources = append(secretResources, r)
+			}
+		}
+	}
+	secrets := extractSecrets(secretResources)
+	if err := writeSecrets(path, output, secrets); err != nil {
+		return nil, err
+	}
+	if p.secretsManifest {
+		if err := writeSecretsManifest(path, secrets); err != nil {
+			return nil, err
+		}
+	}
+	if p.resourceIndex {
+		if err := writeResourceIndex(path, resources); err != nil {
+			return nil, err
+		}
+	}
+	if p.dedupeMonitors {
+		groups, ungrouped := groupIdenticalMonitors(resources)
+		if err := writeMonitorGroups(path, groups); err != nil {
+			return nil, err
+		}
+		resources = ungrouped
+	}
+	if p.idOutputs {
+		if err := writeIDOutputs(path, output, resources); err != nil {
+			return nil, err
+		}
+	}
+	if p.importScript {
+		if err := writeImportScript(path, resources); err != nil {
+			return nil, err
+		}
+	}
+	if p.emitStateV4 {
+		if err := writeStateV4(path, resources); err != nil {
+			return nil, err
+		}
+	}
+	if p.sloDashboards {
+		if err := writeSLODashboards(path, resources); err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+// OutputHook bundle the generated files into the DATADOG_OUTPUT_ZIP archive, then
+// store the start of the incremental export once all its services are written
+func (p *DatadogProvider) OutputHook(path string) error {
+	if p.outputZip != "" {
+		if err := writeOutputZip(path, p.outputZip); err != nil {
+			return err
+		}
+	}
+	if p.lastRunPath == "" {
+		return nil
+	}
+	if !p.importComplete {
+		log.Printf("[WARN] some services failed to import or the export was planned, %s is not updated", p.lastRunPath)
+		return nil
+	}
+	return writeLastRun(p.lastRunPath, p.startedAt)
+}
+
+// jsonServices map the services exporting JSON resources to the typed service
+// exporting the same resources, in the format chosen by DATADOG_MONITOR_FORMAT
+// or DATADOG_DASHBOARD_FORMAT
+var jsonServices = map[string]string{
+	"dashboard_json": "dashboard",
+	"monitor_json":   "monitor",
+}
+
+// OrderServices import the services listed in DATADOG_SERVICE_ORDER first, in
+// that order, then the other requested services, restriction_policy last, even
+// when listed, as it reads the policies of the resources discovered by the others.
+// A JSON service is dropped when its typed service is requested, as with
+// --resources=*, so each resource is exported once in the chosen format.
+func (p *DatadogProvider) OrderServices(services []string) []string {
+	requested := map[string]bool{}
+	for _, service := range services {
+		requested[service] = true
+	}
+	kept := make([]string, 0, len(services))
+	for _, service := range services {
+		if typed, ok := jsonServices[service]; ok && requested[typed] {
+			log.Printf("%s is exported by %s, choose its format with DATADOG_%s_FORMAT", service, typed, strings.ToUpper(typed))
+			delete(requested, service)
+			continue
+		}
+		kept = append(kept, service)
+	}
+	services = kept
+	p.requested = services
+	ordered := make([]string, 0, len(services))
+	for _, service := range p.serviceOrder {
+		if requested[service] && service != "restriction_policy" {
+			ordered = append(ordered, service)
+			delete(requested, service)
+		}
+	}
+	for _, service := range services {
+		if requested[service] && service != "restriction_policy" {
+			ordered = append(ordered, service)
+		}
+	}
+	if requested["restriction_policy"] {
+		ordered = append(ordered, "restriction_policy")
+	}
+	return ordered
+}
+
+// parseServiceOrder parse a comma separated list of supported services
+func parseServiceOrder(value string, supported map[string]terraformutils.ServiceGenerator) ([]string, error) {
+	var services []string
+	for _, service := range strings.Split(value, ",") {
+		service = strings.TrimSpace(service)
+		if _, ok := supported[service]; !ok {
+			return nil, fmt.Errorf("%q is not a supported service", service)
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// SplitPath write the resources of each environment, then of each team, in their own directory
+// when grouping by environment or by team. The incremental exports following the
+// first one only hold the modified resources, they are written in their own
+// incremental/<start time> directory to keep the full export untouched
+func (p *DatadogProvider) SplitPath(path string, resources []terraformutils.Resource) map[string][]terraformutils.Resource {
+	if !p.modifiedSince.IsZero() {
+		path = incrementalPath(path, p.startedAt)
+	}
+	groups := map[string][]terraformutils.Resource{path: resources}
+	if p.groupByEnv {
+		groups = groupByTag(path, "env", resources)
+	}
+	if !p.groupByTeam {
+		return groups
+	}
+	teamGroups := map[string][]terraformutils.Resource{}
+	for envPath, envResources := range groups {
+		for teamPath, teamResources := range groupByTeam(envPath, envResources) {
+			teamGroups[teamPath] = teamResources
+		}
+	}
+	return teamGroups
 }
 
 // GetProviderData return map of provider data for Datadog
 func (p DatadogProvider) GetProviderData(arg ...string) map[string]interface{} {
-	return map[string]interface{}{}
+	if p.providerAlias == "" && !p.providerVars {
+		return map[string]interface{}{}
+	}
+	providerConfig := map[string]interface{}{}
+	if p.providerAlias != "" {
+		providerConfig["alias"] = p.providerAlias
+	}
+	if p.apiURL != "" {
+		providerConfig["api_url"] = p.apiURL
+	}
+	providerData := map[string]interface{}{
+		"provider": map[string]interface{}{
+			p.GetName(): providerConfig,
+		},
+	}
+	if p.providerVars {
+		// the keys are left to the variables, e.g. from TF_VAR_datadog_api_key,
+		// rather than written to the configuration
+		providerConfig["api_key"] = "${var.datadog_api_key}"
+		providerConfig["app_key"] = "${var.datadog_app_key}"
+		providerData["variable"] = map[string]interface{}{
+			"datadog_api_key": map[string]interface{}{"sensitive": true},
+			"datadog_app_key": map[string]interface{}{"sensitive": true},
+		}
+	}
+	return providerData
+}
+
+var siteAliasUnsafeChars = regexp.MustCompile(`[^0-9A-Za-z_]`)
+
+// siteAlias return the provider alias for the Datadog site behind apiURL,
+// e.g. "eu" for https://api.datadoghq.eu/ and "us3" for https://api.us3.datadoghq.com/
+func siteAlias(apiURL string) string {
+	host := "api.datadoghq.com"
+	if parsedAPIURL, err := url.Parse(apiURL); err == nil && parsedAPIURL.Host != "" {
+		host = parsedAPIURL.Host
+	}
+	site := strings.TrimPrefix(host, "api.")
+	switch {
+	case site == "datadoghq.com":
+		return "us1"
+	case site == "datadoghq.eu":
+		return "eu"
+	case site == "ddog-gov.com":
+		return "gov"
+	case strings.HasSuffix(site, ".datadoghq.com"):
+		site = strings.TrimSuffix(site, ".datadoghq.com")
+	}
+	return siteAliasUnsafeChars.ReplaceAllString(site, "_")
 }
@@ -0,0 +1,139 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// parseTestState fill the resource item from its state attributes as the
+// conversion does with the provider schema, impliedType standing for the schema
+func parseTestState(t *testing.T, resource *terraformutils.Resource, impliedType cty.Type) {
+	var ignoreKeys []*regexp.Regexp
+	for _, pattern := range resource.IgnoreKeys {
+		ignoreKeys = append(ignoreKeys, regexp.MustCompile(pattern))
+	}
+	var allowEmptyValues []*regexp.Regexp
+	for _, pattern := range resource.AllowEmptyValues {
+		allowEmptyValues = append(allowEmptyValues, regexp.MustCompile(pattern))
+	}
+	parser := terraformutils.NewFlatmapParser(resource.InstanceState.Attributes, ignoreKeys, allowEmptyValues)
+	if err := resource.ParseTFstate(parser, impliedType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSiteAlias(t *testing.T) {
+	for apiURL, expected := range map[string]string{
+		"":                               "us1",
+		"https://api.datadoghq.com/":     "us1",
+		"https://api.datadoghq.eu/":      "eu",
+		"https://api.us3.datadoghq.com/": "us3",
+		"https://api.ddog-gov.com/":      "gov",
+	} {
+		if alias := siteAlias(apiURL); alias != expected {
+			t.Errorf("expected alias %q for %q, got %q", expected, apiURL, alias)
+		}
+	}
+}
+
+func TestProviderFromVars(t *testing.T) {
+	provider := &DatadogProvider{apiKey: "abcdef", appKey: "ghijkl", providerVars: true}
+	providerData := provider.GetProviderData()
+	providerFile, err := terraformutils.Print(providerData, map[string]struct{}{}, "hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(providerFile), "var.datadog_api_key") || !strings.Contains(string(providerFile), "var.datadog_app_key") {
+		t.Errorf("expected the provider to reference the key variables, got\n%s", providerFile)
+	}
+	if strings.Contains(string(providerFile), "abcdef") || strings.Contains(string(providerFile), "ghijkl") {
+		t.Errorf("unexpected literal key in the provider block\n%s", providerFile)
+	}
+	if _, ok := providerData["variable"].(map[string]interface{})["datadog_api_key"]; !ok {
+		t.Errorf("expected the datadog_api_key variable to be declared, got %v", providerData["variable"])
+	}
+	if providerData := (&DatadogProvider{}).GetProviderData(); len(providerData) != 0 {
+		t.Errorf("unexpected provider data %v", providerData)
+	}
+}
+
+func TestProviderAliasPerSite(t *testing.T) {
+	provider := &DatadogProvider{apiURL: "https://api.datadoghq.eu/"}
+	provider.providerAlias = siteAlias(provider.apiURL)
+
+	providerConfig := provider.GetProviderData()["provider"].(map[string]interface{})["datadog"].(map[string]interface{})
+	if providerConfig["alias"] != "eu" {
+		t.Errorf("expected eu aliased provider, got %v", providerConfig)
+	}
+
+	resource := terraformutils.NewSimpleResource("123", "monitor_123", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	resource.Item = map[string]interface{}{"name": "foo"}
+	g := &MonitorGenerator{}
+	g.Args = map[string]interface{}{"provider-alias": provider.providerAlias}
+	g.Resources = []terraformutils.Resource{resource}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Resources[0].Item["provider"] != "datadog.eu" {
+		t.Errorf("expected resource to reference datadog.eu, got %v", g.Resources[0].Item["provider"])
+	}
+}
+
+func TestServiceOrder(t *testing.T) {
+	provider := &DatadogProvider{}
+	if _, err := parseServiceOrder("monitor,notebook", provider.GetSupportedService()); err == nil {
+		t.Error("expected an error for an unsupported service")
+	}
+	serviceOrder, err := parseServiceOrder("monitor, service_level_objective,dashboard", provider.GetSupportedService())
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.serviceOrder = serviceOrder
+
+	services := provider.OrderServices([]string{"dashboard", "role", "service_level_objective", "monitor"})
+	expected := []string{"monitor", "service_level_objective", "dashboard", "role"}
+	if !reflect.DeepEqual(services, expected) {
+		t.Fatalf("expected services to run in order %v, got %v", expected, services)
+	}
+
+	// restriction_policy reads the policies of the resources the others discovered
+	provider.serviceOrder = []string{"restriction_policy", "monitor"}
+	services = provider.OrderServices([]string{"dashboard", "restriction_policy", "monitor"})
+	expected = []string{"monitor", "dashboard", "restriction_policy"}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("expected restriction_policy to run last %v, got %v", expected, services)
+	}
+
+	// the typed services export the JSON resources in the chosen format
+	provider.serviceOrder = nil
+	services = provider.OrderServices([]string{"dashboard", "dashboard_json", "monitor", "monitor_json", "role"})
+	expected = []string{"dashboard", "monitor", "role"}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("expected the JSON services left out %v, got %v", expected, services)
+	}
+	services = provider.OrderServices([]string{"monitor_json", "role"})
+	expected = []string{"monitor_json", "role"}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("expected a JSON service requested alone to be kept %v, got %v", expected, services)
+	}
+}
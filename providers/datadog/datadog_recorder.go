@@ -0,0 +1,198 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	recordModeRecord = "record"
+	recordModeReplay = "replay"
+
+	scrubbedSecret = "REDACTED"
+)
+
+// cassetteInteraction is one recorded request and its response
+type cassetteInteraction struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// recorderTransport record the Datadog API answers into a cassette file, or
+// replay them from it so exports can be tested without credentials.
+// Credentials are never written: auth headers are not recorded and the keys
+// are scrubbed from the recorded urls and bodies.
+type recorderTransport struct {
+	mode     string
+	cassette string
+	secrets  []string
+	next     http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []cassetteInteraction
+	replayed     []bool
+}
+
+// newRecorderTransport return a transport recording into or replaying from cassette depending on mode
+func newRecorderTransport(mode, cassette string, secrets []string) (*recorderTransport, error) {
+	t := &recorderTransport{
+		mode:     mode,
+		cassette: cassette,
+		next:     http.DefaultTransport,
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			t.secrets = append(t.secrets, secret)
+		}
+	}
+
+	switch mode {
+	case recordModeRecord:
+		return t, nil
+	case recordModeReplay:
+		data, err := ioutil.ReadFile(cassette)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &t.interactions); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s : %v", cassette, err)
+		}
+		t.replayed = make([]bool, len(t.interactions))
+		return t, nil
+	default:
+		return nil, fmt.Errorf("unknown record mode %q, expected %q or %q", mode, recordModeRecord, recordModeReplay)
+	}
+}
+
+// newHTTPClient return the http client shared by the V1 and V2 API clients
+func newHTTPClient(mode, cassette string, secrets []string) (*http.Client, error) {
+	if mode == "" {
+		return &http.Client{Transport: http.DefaultTransport}, nil
+	}
+	transport, err := newRecorderTransport(mode, cassette, secrets)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == recordModeReplay {
+		return t.replay(req)
+	}
+	return t.record(req)
+}
+
+func (t *recorderTransport) replay(req *http.Request) (*http.Response, error) {
+	requestURL := t.scrub(requestURI(req.URL))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.interactions {
+		if t.replayed[i] || interaction.Method != req.Method || interaction.URL != requestURL {
+			continue
+		}
+		t.replayed[i] = true
+		header := http.Header{}
+		if interaction.ContentType != "" {
+			header.Set("Content-Type", interaction.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Body)),
+			ContentLength: int64(len(interaction.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction left in %s for %s %s", t.cassette, req.Method, requestURL)
+}
+
+func (t *recorderTransport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, cassetteInteraction{
+		Method:      req.Method,
+		URL:         t.scrub(requestURI(req.URL)),
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        t.scrub(string(body)),
+	})
+	// The cassette is rewritten after each interaction as exports don't close their clients
+	return resp, t.save()
+}
+
+func (t *recorderTransport) save() error {
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.cassette), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(t.cassette, append(data, '\n'), os.ModePerm)
+}
+
+// scrub replace the credentials found in s
+func (t *recorderTransport) scrub(s string) string {
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, scrubbedSecret)
+	}
+	return s
+}
+
+// requestURI return the path and the sorted query of u without the credentials
+// passed as query parameters, the host is left out so cassettes can be
+// replayed against any site
+func requestURI(u *url.URL) string {
+	query := u.Query()
+	for _, key := range []string{"api_key", "application_key"} {
+		if query.Get(key) != "" {
+			query.Set(key, scrubbedSecret)
+		}
+	}
+	if len(query) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + query.Encode()
+}
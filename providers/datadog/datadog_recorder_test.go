@@ -0,0 +1,106 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// newRecordedClientV1 return a V1 client and auth context replaying testdata/cassettes/<name>.json.
+// Run the tests with DATADOG_RECORD_MODE=record, DATADOG_API_KEY and DATADOG_APP_KEY
+// to record the cassette again against a real organization.
+func newRecordedClientV1(t *testing.T, name string) (*datadogV1.APIClient, context.Context) {
+	mode := recordModeReplay
+	apiKey, appKey := "api-key", "app-key"
+	if os.Getenv("DATADOG_RECORD_MODE") == recordModeRecord {
+		mode = recordModeRecord
+		apiKey, appKey = os.Getenv("DATADOG_API_KEY"), os.Getenv("DATADOG_APP_KEY")
+	}
+
+	httpClient, err := newHTTPClient(mode, filepath.Join("testdata", "cassettes", name+".json"), []string{apiKey, appKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := datadogV1.NewConfiguration()
+	config.HTTPClient = httpClient
+	auth := context.WithValue(context.Background(), datadogV1.ContextAPIKeys, map[string]datadogV1.APIKey{
+		"apiKeyAuth": {Key: apiKey},
+		"appKeyAuth": {Key: appKey},
+	})
+	return datadogV1.NewAPIClient(config), auth
+}
+
+func TestDashboardGeneratorRecorded(t *testing.T) {
+	// each subtest replays its own cassette, recording them again would
+	// otherwise let the last subtest overwrite the interactions of the others
+	for _, tc := range []struct {
+		name     string
+		cassette string
+		filter   []terraformutils.ResourceFilter
+		expects  []string
+	}{
+		{
+			name:     "list",
+			cassette: "dashboard_list",
+			expects:  []string{"abc-def-ghi", "jkl-mno-pqr"},
+		},
+		{
+			name:     "filter by id",
+			cassette: "dashboard_get",
+			filter: []terraformutils.ResourceFilter{{
+				ServiceName:      "dashboard",
+				FieldPath:        "id",
+				AcceptableValues: []string{"abc-def-ghi"},
+			}},
+			expects: []string{"abc-def-ghi"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, auth := newRecordedClientV1(t, tc.cassette)
+			g := &DashboardGenerator{}
+			g.Filter = tc.filter
+			g.SetArgs(map[string]interface{}{
+				"datadogClientV1": client,
+				"authV1":          auth,
+			})
+			if err := g.InitResources(); err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, resource := range g.Resources {
+				ids = append(ids, resource.InstanceState.ID)
+			}
+			if !reflect.DeepEqual(ids, tc.expects) {
+				t.Errorf("expected dashboards %v, got %v", tc.expects, ids)
+			}
+		})
+	}
+}
+
+func TestRecorderScrubsCredentials(t *testing.T) {
+	transport := &recorderTransport{secrets: []string{"secret-api-key"}}
+	if got := transport.scrub(`{"key":"secret-api-key"}`); got != `{"key":"REDACTED"}` {
+		t.Errorf("credentials were not scrubbed: %s", got)
+	}
+}
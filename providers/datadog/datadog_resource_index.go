@@ -0,0 +1,81 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// resourceIndexAttributes list the attributes describing best each resource
+// type in the resource index, "name" and "title" are listed for all of them
+var resourceIndexAttributes = map[string][]string{
+	"datadog_monitor":                  {"type", "query"},
+	"datadog_dashboard":                {"layout_type"},
+	"datadog_logs_index":               {"filter.0.query"},
+	"datadog_logs_metric":              {"filter.0.query"},
+	"datadog_security_monitoring_rule": {"type"},
+	"datadog_service_level_objective":  {"type"},
+	"datadog_synthetics_test":          {"type", "subtype", "request_definition.0.url"},
+	"datadog_user":                     {"email"},
+}
+
+// writeResourceIndex write RESOURCES.md listing the resources exported to path
+func writeResourceIndex(path string, resources []terraformutils.Resource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	sorted := append([]terraformutils.Resource{}, resources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].InstanceInfo.Type != sorted[j].InstanceInfo.Type {
+			return sorted[i].InstanceInfo.Type < sorted[j].InstanceInfo.Type
+		}
+		return sorted[i].ResourceName < sorted[j].ResourceName
+	})
+
+	var b strings.Builder
+	b.WriteString("# Exported resources\n\n")
+	b.WriteString("| Type | Name | ID | Attributes |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, r := range sorted {
+		var attributes []string
+		for _, attribute := range append([]string{"name", "title"}, resourceIndexAttributes[r.InstanceInfo.Type]...) {
+			if value := r.InstanceState.Attributes[attribute]; value != "" {
+				attributes = append(attributes, fmt.Sprintf("%s: `%s`", attribute, value))
+			}
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+			r.InstanceInfo.Type,
+			escapeMarkdownCell(r.ResourceName),
+			escapeMarkdownCell(r.InstanceState.ID),
+			escapeMarkdownCell(strings.Join(attributes, "<br>")),
+		)
+	}
+
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/RESOURCES.md", []byte(b.String()), os.ModePerm)
+}
+
+// escapeMarkdownCell escape what would break a markdown table cell
+func escapeMarkdownCell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(value)
+}
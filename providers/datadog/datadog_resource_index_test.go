@@ -0,0 +1,46 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestWriteResourceIndex(t *testing.T) {
+	monitor := terraformutils.NewResource("1", "monitor_1", "datadog_monitor", "datadog", map[string]string{
+		"id":    "1",
+		"name":  "High CPU",
+		"type":  "metric alert",
+		"query": "avg(last_5m):avg:system.cpu.user{env:prod|env:staging} > 90",
+	}, MonitorAllowEmptyValues, map[string]interface{}{})
+
+	path := t.TempDir()
+	if err := writeResourceIndex(path, []terraformutils.Resource{monitor}); err != nil {
+		t.Fatal(err)
+	}
+	index, err := ioutil.ReadFile(path + "/RESOURCES.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "| datadog_monitor | tfer--monitor_1 | 1 | name: `High CPU`<br>type: `metric alert`<br>query: `avg(last_5m):avg:system.cpu.user{env:prod\\|env:staging} > 90` |"
+	if !strings.Contains(string(index), expected) {
+		t.Errorf("monitor not listed with its query:\n%s", index)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// retryTransport retry the requests failing on a rate limit, a server error or
+// a transient network error, with an exponential backoff
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+// newRetryTransport return a transport retrying the requests to next up to retries times
+func newRetryTransport(next http.RoundTripper, retries int, backoff time.Duration) *retryTransport {
+	return &retryTransport{next: next, retries: retries, backoff: backoff}
+}
+
+// retryableStatus return true for the HTTP statuses worth retrying, rate
+// limits and server errors
+func retryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// transientNetError return true for the network errors worth retrying, the
+// connection reset by the peer or closed early and the temporary DNS failures
+func transientNetError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		retry := false
+		switch {
+		case err != nil:
+			retry = transientNetError(err)
+		default:
+			retry = retryableStatus(resp.StatusCode)
+		}
+		if !retry || attempt >= t.retries {
+			return resp, err
+		}
+		// The request body is read by each attempt, it can only be sent again if it can be rewound
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportConnectionReset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	attempts := 0
+	transport := newRetryTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	}), 3, 0)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if attempts != 2 || resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "data") {
+		t.Errorf("expected a successful retry after the connection reset, got %d attempts and status %d", attempts, resp.StatusCode)
+	}
+}
+
+func TestRetryTransportClassification(t *testing.T) {
+	for _, err := range []error{
+		os.NewSyscallError("read", syscall.ECONNRESET),
+		&net.DNSError{Err: "server misbehaving", Name: "api.datadoghq.com", IsTemporary: true},
+	} {
+		if !transientNetError(err) {
+			t.Errorf("expected %v to be transient", err)
+		}
+	}
+	for _, err := range []error{
+		errors.New("x509: certificate signed by unknown authority"),
+		&net.DNSError{Err: "no such host", Name: "api.datadoghq.invalid", IsNotFound: true},
+	} {
+		if transientNetError(err) {
+			t.Errorf("unexpected transient error %v", err)
+		}
+	}
+
+	// the permanent errors are returned without retrying
+	attempts := 0
+	transport := newRetryTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errors.New("x509: certificate signed by unknown authority")
+	}), 3, 0)
+	req, _ := http.NewRequest(http.MethodGet, "https://api.datadoghq.com/api/v1/validate", nil)
+	if _, err := transport.RoundTrip(req); err == nil || attempts != 1 {
+		t.Errorf("expected a single attempt on a permanent error, got %d", attempts)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"sort"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// sampler keep at most size resources of a service, the first ones by id so
+// two previews of the same organization export the same resources
+type sampler struct {
+	terraformutils.ServiceGenerator
+	size int
+}
+
+// InitResources ...
+func (g *sampler) InitResources() error {
+	if err := g.ServiceGenerator.InitResources(); err != nil {
+		return err
+	}
+	g.SetResources(sampleResources(g.GetResources(), g.size))
+	return nil
+}
+
+// sampleResources return the first size resources by id
+func sampleResources(resources []terraformutils.Resource, size int) []terraformutils.Resource {
+	if len(resources) <= size {
+		return resources
+	}
+	sorted := append([]terraformutils.Resource{}, resources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].InstanceState.ID < sorted[j].InstanceState.ID
+	})
+	return sorted[:size]
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+type sampleTestGenerator struct {
+	DatadogService
+}
+
+func (g *sampleTestGenerator) InitResources() error {
+	for i := 10; i > 0; i-- {
+		id := fmt.Sprint(i)
+		g.Resources = append(g.Resources, terraformutils.NewSimpleResource(id, "monitor_"+id, "datadog_monitor", "datadog", MonitorAllowEmptyValues))
+	}
+	return nil
+}
+
+func TestSampler(t *testing.T) {
+	g := &sampler{ServiceGenerator: &sampleTestGenerator{}, size: 3}
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	resources := g.GetResources()
+	if len(resources) != 3 {
+		t.Fatalf("expected 3 monitors, got %d", len(resources))
+	}
+	for i, id := range []string{"1", "10", "2"} {
+		if resources[i].InstanceState.ID != id {
+			t.Errorf("expected monitor %s at %d, got %s", id, i, resources[i].InstanceState.ID)
+		}
+	}
+}
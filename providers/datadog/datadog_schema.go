@@ -0,0 +1,133 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// schemaBlock is a block of the provider schema written by
+// terraform providers schema -json, only its attribute and block names
+type schemaBlock struct {
+	Attributes map[string]json.RawMessage `json:"attributes"`
+	BlockTypes map[string]struct {
+		Block schemaBlock `json:"block"`
+	} `json:"block_types"`
+}
+
+// metaArguments list the top level arguments every resource accepts
+var metaArguments = map[string]bool{
+	"count":      true,
+	"depends_on": true,
+	"for_each":   true,
+	"lifecycle":  true,
+	"provider":   true,
+	"//":         true,
+}
+
+// loadProviderSchema read the datadog resource schemas from the output of
+// terraform providers schema -json, by resource type
+func loadProviderSchema(path string) (map[string]schemaBlock, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schema struct {
+		ProviderSchemas map[string]struct {
+			ResourceSchemas map[string]struct {
+				Block schemaBlock `json:"block"`
+			} `json:"resource_schemas"`
+		} `json:"provider_schemas"`
+	}
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, err
+	}
+	for source, provider := range schema.ProviderSchemas {
+		if source != "datadog" && !strings.HasSuffix(source, "/datadog") {
+			continue
+		}
+		resources := map[string]schemaBlock{}
+		for resourceType, resource := range provider.ResourceSchemas {
+			resources[resourceType] = resource.Block
+		}
+		return resources, nil
+	}
+	return nil, fmt.Errorf("no datadog provider schema in %s", path)
+}
+
+// unknownAttributes return the path of the attributes of item the block
+// doesn't declare, nested blocks included
+func unknownAttributes(prefix string, item map[string]interface{}, block schemaBlock) []string {
+	var unknown []string
+	for key, value := range item {
+		if _, ok := block.Attributes[key]; ok {
+			continue
+		}
+		blockType, ok := block.BlockTypes[key]
+		if !ok {
+			unknown = append(unknown, prefix+key)
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			unknown = append(unknown, unknownAttributes(prefix+key+".", v, blockType.Block)...)
+		case []interface{}:
+			for _, element := range v {
+				if nested, ok := element.(map[string]interface{}); ok {
+					unknown = append(unknown, unknownAttributes(prefix+key+".", nested, blockType.Block)...)
+				}
+			}
+		}
+	}
+	return unknown
+}
+
+// schemaWarnings return a warning for each attribute of the imported
+// resources the provider schema doesn't know, the provider version in use
+// would reject it
+func schemaWarnings(importedResource map[string][]terraformutils.Resource, schemas map[string]schemaBlock) []string {
+	var warnings []string
+	for _, resources := range importedResource {
+		for _, r := range resources {
+			address := r.InstanceInfo.Type + "." + r.ResourceName
+			block, ok := schemas[r.InstanceInfo.Type]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("%s type is unknown to the provider", address))
+				continue
+			}
+			item := map[string]interface{}{}
+			for key, value := range r.Item {
+				if !metaArguments[key] {
+					item[key] = value
+				}
+			}
+			seen := map[string]bool{}
+			for _, attribute := range unknownAttributes("", item, block) {
+				if !seen[attribute] {
+					seen[attribute] = true
+					warnings = append(warnings, fmt.Sprintf("%s %s attribute is unknown to the provider", address, attribute))
+				}
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestSchemaWarnings(t *testing.T) {
+	path := t.TempDir() + "/schema.json"
+	schema := `{"format_version": "1.0", "provider_schemas": {"registry.terraform.io/datadog/datadog": {"resource_schemas": {
+		"datadog_monitor": {"version": 0, "block": {
+			"attributes": {"id": {"type": "string"}, "name": {"type": "string"}, "query": {"type": "string"}},
+			"block_types": {"monitor_thresholds": {"nesting_mode": "list", "block": {"attributes": {"critical": {"type": "string"}}}}}
+		}}
+	}}}}`
+	if err := ioutil.WriteFile(path, []byte(schema), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := loadProviderSchema(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitor := terraformutils.NewSimpleResource("1", "monitor_1", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	monitor.Item = map[string]interface{}{
+		"name":               "cpu",
+		"query":              "avg(last_5m):avg:system.cpu.user{*} > 90",
+		"renotify_statuses":  []interface{}{"alert"},
+		"monitor_thresholds": []interface{}{map[string]interface{}{"critical": "90", "critical_recovery": "80"}},
+		"depends_on":         []interface{}{"datadog_user.tfer--user_1"},
+	}
+	warnings := schemaWarnings(map[string][]terraformutils.Resource{"monitor": {monitor}}, schemas)
+	expected := []string{
+		"datadog_monitor." + monitor.ResourceName + " monitor_thresholds.critical_recovery attribute is unknown to the provider",
+		"datadog_monitor." + monitor.ResourceName + " renotify_statuses attribute is unknown to the provider",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// secretAttribute is an attribute holding a secret, needed when the resource
+// sets the attribute when to a value other than false, or always when empty
+type secretAttribute struct {
+	name string
+	when string
+}
+
+// secretAttributes list the attributes holding a secret, by resource type.
+// The API doesn't return most of them, they must be provided at apply time.
+var secretAttributes = map[string][]secretAttribute{
+	"datadog_integration_aws":                      {{name: "secret_access_key", when: "access_key_id"}},
+	"datadog_integration_azure":                    {{name: "client_secret"}},
+	"datadog_integration_cloudflare_account":       {{name: "api_key"}},
+	"datadog_integration_confluent_account":        {{name: "api_secret"}},
+	"datadog_integration_fastly_account":           {{name: "api_key"}},
+	"datadog_integration_gcp":                      {{name: "private_key"}},
+	"datadog_integration_opsgenie_service_object":  {{name: "opsgenie_api_key"}},
+	"datadog_integration_pagerduty":                {{name: "api_token"}},
+	"datadog_integration_pagerduty_service_object": {{name: "service_key"}},
+	"datadog_webhook":                              {{name: "custom_headers", when: "custom_headers"}},
+	"datadog_webhook_custom_variable":              {{name: "value", when: "is_secret"}},
+}
+
+// redactedTypes list the resource types whose secrets are always replaced by
+// sensitive variables, the API returns no value or a masked one for them
+var redactedTypes = map[string]bool{
+	"datadog_integration_cloudflare_account":       true,
+	"datadog_integration_confluent_account":        true,
+	"datadog_integration_fastly_account":           true,
+	"datadog_integration_pagerduty":                true,
+	"datadog_integration_pagerduty_service_object": true,
+	"datadog_webhook_custom_variable":              true,
+}
+
+// extractSecrets replace the secret attributes of resources by references to
+// sensitive variables and return the secret of each variable
+func extractSecrets(resources []terraformutils.Resource) map[string]string {
+	secrets := map[string]string{}
+	for _, r := range resources {
+		for _, attribute := range secretAttributes[r.InstanceInfo.Type] {
+			if when := r.InstanceState.Attributes[attribute.when]; attribute.when != "" && (when == "" || when == "false") {
+				continue
+			}
+			name := strings.TrimPrefix(r.ResourceName, "tfer--") + "_" + attribute.name
+			r.Item[attribute.name] = "${var." + name + "}"
+			secrets[name] = fmt.Sprintf("%s.%s %s", r.InstanceInfo.Type, r.ResourceName, attribute.name)
+		}
+	}
+	return secrets
+}
+
+// secretFingerprints return a comment with the SHA-256 fingerprint of each
+// secret attribute of r, computed on the masked value returned by the API, to
+// tell which secrets changed between two exports without storing them
+func secretFingerprints(r terraformutils.Resource) []string {
+	var fingerprints []string
+	for _, attribute := range secretAttributes[r.InstanceInfo.Type] {
+		value := r.InstanceState.Attributes[attribute.name]
+		if value == "" {
+			continue
+		}
+		fingerprints = append(fingerprints, fmt.Sprintf("%s sha256:%x", attribute.name, sha256.Sum256([]byte(value))))
+	}
+	return fingerprints
+}
+
+// writeSecrets write the sensitive variable declarations and a
+// secrets.auto.tfvars.example to fill, and to keep out of version control
+// once renamed, to path
+func writeSecrets(path, output string, secrets map[string]string) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	declarations := map[string]interface{}{}
+	for name := range secrets {
+		declarations[name] = map[string]interface{}{"sensitive": true}
+	}
+	variablesFile, err := terraformutils.Print(map[string]interface{}{"variable": declarations}, map[string]struct{}{}, output)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+"/secrets_variables."+terraformoutput.GetFileExtension(output), variablesFile, os.ModePerm); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var example strings.Builder
+	example.WriteString("# Rename to secrets.auto.tfvars once filled, and keep it out of version control.\n")
+	for _, name := range names {
+		fmt.Fprintf(&example, "\n# TODO: set %s\n%s = \"\"\n", secrets[name], name)
+	}
+	return ioutil.WriteFile(path+"/secrets.auto.tfvars.example", []byte(example.String()), os.ModePerm)
+}
+
+// writeSecretsManifest write a secrets_manifest.md mapping each sensitive
+// variable to the resource attribute it replaces, one sorted row by variable
+// so the manifests of two exports diff line by line
+func writeSecretsManifest(path string, secrets map[string]string) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("# Secret variables\n\n")
+	b.WriteString("| Variable | Resource | Attribute |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	for _, name := range names {
+		// The secret is '<type>.<resource name> <attribute>'
+		source := strings.SplitN(secrets[name], " ", 2)
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", name, escapeMarkdownCell(source[0]), source[1])
+	}
+	return ioutil.WriteFile(path+"/secrets_manifest.md", []byte(b.String()), os.ModePerm)
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestSecretsFile(t *testing.T) {
+	webhook := terraformutils.NewResource("alerts", "webhook_alerts", "datadog_webhook", "datadog", map[string]string{
+		"id":             "alerts",
+		"name":           "alerts",
+		"custom_headers": `{"Authorization": "Bearer xxx"}`,
+	}, []string{}, map[string]interface{}{})
+	webhook.Item = map[string]interface{}{
+		"name":           "alerts",
+		"custom_headers": `{"Authorization": "Bearer xxx"}`,
+	}
+
+	path := t.TempDir()
+	if err := writeSecrets(path, "hcl", extractSecrets([]terraformutils.Resource{webhook})); err != nil {
+		t.Fatal(err)
+	}
+
+	if customHeaders := webhook.Item["custom_headers"]; customHeaders != "${var.webhook_alerts_custom_headers}" {
+		t.Errorf("expected the webhook secret to reference its variable, got %v", customHeaders)
+	}
+	example, err := ioutil.ReadFile(path + "/secrets.auto.tfvars.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(example), "# TODO: set datadog_webhook.tfer--webhook_alerts custom_headers\nwebhook_alerts_custom_headers = \"\"\n") {
+		t.Errorf("unexpected secrets.auto.tfvars.example:\n%s", example)
+	}
+	if strings.Contains(string(example), "Bearer") {
+		t.Errorf("secret values must not be written:\n%s", example)
+	}
+	declarations, err := ioutil.ReadFile(path + "/secrets_variables.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(declarations), "sensitive = true") {
+		t.Errorf("expected sensitive variables:\n%s", declarations)
+	}
+}
+
+func TestSecretFingerprints(t *testing.T) {
+	export := func() interface{} {
+		integration := terraformutils.NewResource("tenant:client", "integration_azure_tenant", "datadog_integration_azure", "datadog", map[string]string{
+			"id":            "tenant:client",
+			"client_secret": "*****wxyz",
+		}, []string{}, map[string]interface{}{})
+		integration.Item = map[string]interface{}{"client_id": "client"}
+
+		g := &IntegrationAzureGenerator{}
+		g.SetArgs(map[string]interface{}{"secret-fingerprints": true})
+		g.Resources = []terraformutils.Resource{integration}
+		if err := g.PostConvertHook(); err != nil {
+			t.Fatal(err)
+		}
+		return g.Resources[0].Item["//"]
+	}
+
+	first, second := export(), export()
+	if first != second {
+		t.Errorf("expected a stable fingerprint, got %v then %v", first, second)
+	}
+	comment, _ := first.(string)
+	if !strings.HasPrefix(comment, "client_secret sha256:") || strings.Contains(comment, "wxyz") {
+		t.Errorf("unexpected fingerprint comment %q", comment)
+	}
+}
+
+func TestSecretsManifest(t *testing.T) {
+	variable := terraformutils.NewResource("abc", "webhook_custom_variable_TOKEN", "datadog_webhook_custom_variable", "datadog", map[string]string{
+		"id":        "abc",
+		"name":      "TOKEN",
+		"is_secret": "true",
+	}, []string{}, map[string]interface{}{})
+	variable.Item = map[string]interface{}{"name": "TOKEN", "is_secret": true}
+
+	path := t.TempDir()
+	if err := writeSecretsManifest(path, extractSecrets([]terraformutils.Resource{variable})); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := ioutil.ReadFile(path + "/secrets_manifest.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	row := "| webhook_custom_variable_TOKEN_value | datadog_webhook_custom_variable." + variable.ResourceName + " | value |\n"
+	if !strings.Contains(string(manifest), row) {
+		t.Errorf("expected the manifest to map the placeholder to its resource, got:\n%s", manifest)
+	}
+}
@@ -14,8 +14,136 @@
 
 package datadog
 
-import "github.com/GoogleCloudPlatform/terraformer/terraformutils"
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
 
 type DatadogService struct { //nolint
 	terraformutils.Service
 }
+
+// PostConvertHook applies the export options shared by all Datadog services.
+// Generators overriding it must call it once done with their own changes.
+func (s *DatadogService) PostConvertHook() error {
+	if patterns, ok := s.Args["ignore-patterns"].([]string); ok && len(patterns) > 0 {
+		resources := make([]terraformutils.Resource, 0, len(s.Resources))
+		for _, r := range s.Resources {
+			if !isIgnored(r, patterns) {
+				resources = append(resources, r)
+			}
+		}
+		s.Resources = resources
+	}
+	// Record the resources which can be restricted for restriction_policy
+	if targets, ok := s.Args["restriction-targets"].(map[string]bool); ok {
+		for _, r := range s.Resources {
+			if resourceID := restrictionPolicyResourceID(r); resourceID != "" {
+				targets[resourceID] = true
+			}
+		}
+	}
+	if tag, ok := s.Args["managed-tag"].(string); ok && tag != "" {
+		for i, r := range s.Resources {
+			addManagedTag(s.Resources[i].Item, r.InstanceInfo.Type, tag)
+		}
+	}
+	if alias, ok := s.Args["provider-alias"].(string); ok && alias != "" {
+		for i := range s.Resources {
+			s.Resources[i].Item["provider"] = "datadog." + alias
+		}
+	}
+	if annotate, ok := s.Args["annotate-provider-versions"].(bool); ok && annotate {
+		for i, r := range s.Resources {
+			if comment := providerVersionComment(r); comment != "" {
+				addComment(s.Resources[i].Item, comment)
+			}
+		}
+	}
+	if fingerprints, ok := s.Args["secret-fingerprints"].(bool); ok && fingerprints {
+		for i, r := range s.Resources {
+			for _, comment := range secretFingerprints(r) {
+				addComment(s.Resources[i].Item, comment)
+			}
+		}
+	}
+	if ignoreChanges, ok := s.Args["ignore-changes"].(map[string][]string); ok {
+		for i, r := range s.Resources {
+			attributes := ignoreChanges[r.InstanceInfo.Type]
+			if len(attributes) == 0 {
+				continue
+			}
+			ignored := make([]interface{}, 0, len(attributes))
+			for _, attribute := range attributes {
+				ignored = append(ignored, attribute)
+			}
+			s.Resources[i].Item["lifecycle"] = []interface{}{
+				map[string]interface{}{"ignore_changes": ignored},
+			}
+		}
+	}
+	return nil
+}
+
+// addComment add a comment line to item, "//" is a comment in Terraform JSON
+// syntax, printed as one in HCL
+func addComment(item map[string]interface{}, comment string) {
+	if previous, ok := item["//"].(string); ok && previous != "" {
+		comment = previous + "\n" + comment
+	}
+	item["//"] = comment
+}
+
+// ParseFilters add the filter selecting the resource targeted by DATADOG_TARGET_URL to the user ones
+func (s *DatadogService) ParseFilters(rawFilters []string) {
+	s.Service.ParseFilters(rawFilters)
+	if target, ok := s.Args["target"].(*terraformutils.ResourceFilter); ok && target != nil {
+		s.Filter = append(s.Filter, *target)
+	}
+}
+
+// isModified return true if the resource modified at modifiedAt must be
+// exported, when the export is limited to the resources modified since the
+// last incremental run. Resources without a modification time are kept.
+func (s *DatadogService) isModified(modifiedAt time.Time) bool {
+	since, ok := s.Args["modified-since"].(time.Time)
+	return !ok || since.IsZero() || modifiedAt.IsZero() || modifiedAt.After(since)
+}
+
+// isOwned return true if the resource authored by handle must be exported,
+// when the export is limited to the resources owned by the current user
+func (s *DatadogService) isOwned(handle string) bool {
+	owner, ok := s.Args["owner-handle"].(string)
+	return !ok || owner == "" || strings.EqualFold(owner, handle)
+}
+
+// defaultIgnoreChanges list the server computed attributes drifting after each apply, by resource type
+var defaultIgnoreChanges = map[string][]string{
+	"datadog_monitor": {"overall_state"},
+}
+
+// parseIgnoreChanges parse DATADOG_IGNORE_CHANGES: either a boolean enabling
+// the default ignore lists, or a comma separated list of
+// `<resource_type>:<attribute>` added to them
+func parseIgnoreChanges(value string) (map[string][]string, error) {
+	var fields []tfvarsField
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		if !enabled {
+			return nil, nil
+		}
+	} else if fields, err = parseTfvarsFields(value); err != nil {
+		return nil, err
+	}
+
+	ignoreChanges := map[string][]string{}
+	for resourceType, attributes := range defaultIgnoreChanges {
+		ignoreChanges[resourceType] = append([]string{}, attributes...)
+	}
+	for _, field := range fields {
+		ignoreChanges[field.resourceType] = append(ignoreChanges[field.resourceType], field.path)
+	}
+	return ignoreChanges, nil
+}
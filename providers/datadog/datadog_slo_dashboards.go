@@ -0,0 +1,89 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// sloDashboards return a summary dashboard per team: tag, listing the team
+// SLOs as slo widgets referencing the exported SLOs
+func sloDashboards(resources []terraformutils.Resource) map[string]interface{} {
+	slos := map[string][]terraformutils.Resource{}
+	for _, r := range resources {
+		if r.InstanceInfo.Type != "datadog_service_level_objective" {
+			continue
+		}
+		if team := resourceTeam(r); team != "" {
+			slos[team] = append(slos[team], r)
+		}
+	}
+
+	dashboards := map[string]interface{}{}
+	for team, teamSLOs := range slos {
+		sort.Slice(teamSLOs, func(i, j int) bool {
+			return teamSLOs[i].ResourceName < teamSLOs[j].ResourceName
+		})
+		widgets := make([]interface{}, 0, len(teamSLOs))
+		for _, slo := range teamSLOs {
+			widgets = append(widgets, map[string]interface{}{
+				"service_level_objective_definition": []interface{}{map[string]interface{}{
+					"title":        slo.InstanceState.Attributes["name"],
+					"slo_id":       "${datadog_service_level_objective." + slo.ResourceName + ".id}",
+					"view_type":    "detail",
+					"view_mode":    "overall",
+					"time_windows": []interface{}{"7d", "30d", "90d"},
+				}},
+			})
+		}
+		dashboards["slo_summary_"+unsafeTeamPathChars.ReplaceAllString(team, "_")] = map[string]interface{}{
+			"title":       team + " SLOs",
+			"layout_type": "ordered",
+			"widget":      widgets,
+		}
+	}
+	return dashboards
+}
+
+// writeSLODashboards write the team SLO summary dashboards of resources to
+// slo_dashboards.tf.json under path. They are new resources, left out of the
+// exported state and created by the next apply.
+func writeSLODashboards(path string, resources []terraformutils.Resource) error {
+	dashboards := sloDashboards(resources)
+	if len(dashboards) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	var dashboardsFile bytes.Buffer
+	encoder := json.NewEncoder(&dashboardsFile)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(map[string]interface{}{
+		"resource": map[string]interface{}{"datadog_dashboard": dashboards},
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/slo_dashboards.tf.json", dashboardsFile.Bytes(), os.ModePerm)
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestSLODashboards(t *testing.T) {
+	newSLO := func(id, name, team string) terraformutils.Resource {
+		return terraformutils.NewResource(id, "service_level_objective_"+id, "datadog_service_level_objective", "datadog", map[string]string{
+			"id":     id,
+			"name":   name,
+			"tags.#": "1",
+			"tags.0": "team:" + team,
+		}, ServiceLevelObjectiveAllowEmptyValues, map[string]interface{}{})
+	}
+	resources := []terraformutils.Resource{
+		newSLO("abc", "Checkout availability", "payments"),
+		newSLO("def", "Checkout latency", "payments"),
+		newSLO("ghi", "Search latency", "search"),
+	}
+
+	path := t.TempDir()
+	if err := writeSLODashboards(path, resources); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path + "/slo_dashboards.tf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Resource struct {
+			Dashboards map[string]struct {
+				Title  string `json:"title"`
+				Widget []struct {
+					Definition []struct {
+						SLOID string `json:"slo_id"`
+					} `json:"service_level_objective_definition"`
+				} `json:"widget"`
+			} `json:"datadog_dashboard"`
+		} `json:"resource"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Resource.Dashboards) != 2 {
+		t.Fatalf("expected a dashboard per team, got %s", data)
+	}
+	payments := file.Resource.Dashboards["slo_summary_payments"]
+	if payments.Title != "payments SLOs" || len(payments.Widget) != 2 {
+		t.Fatalf("expected the payments dashboard to list its 2 SLOs, got %s", data)
+	}
+	for i, expected := range []string{
+		"${datadog_service_level_objective.tfer--service_level_objective_abc.id}",
+		"${datadog_service_level_objective.tfer--service_level_objective_def.id}",
+	} {
+		if sloID := payments.Widget[i].Definition[0].SLOID; sloID != expected {
+			t.Errorf("expected widget %d to reference %s, got %s", i, expected, sloID)
+		}
+	}
+}
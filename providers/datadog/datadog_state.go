@@ -0,0 +1,121 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// datadogProviderAddress is the registry address of the Datadog provider in the state
+const datadogProviderAddress = `provider["registry.terraform.io/datadog/datadog"]`
+
+// stateV4 is a minimal Terraform 0.13+ state, the instances keep the flat
+// attributes of the refreshed state, upgraded by the provider on the next plan
+type stateV4 struct {
+	Version          int                    `json:"version"`
+	TerraformVersion string                 `json:"terraform_version"`
+	Serial           int                    `json:"serial"`
+	Lineage          string                 `json:"lineage"`
+	Outputs          map[string]interface{} `json:"outputs"`
+	Resources        []stateV4Resource      `json:"resources"`
+}
+
+type stateV4Resource struct {
+	Mode      string            `json:"mode"`
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	Provider  string            `json:"provider"`
+	Instances []stateV4Instance `json:"instances"`
+}
+
+type stateV4Instance struct {
+	SchemaVersion  int               `json:"schema_version"`
+	AttributesFlat map[string]string `json:"attributes_flat"`
+}
+
+// newStateV4 return the state of resources, sorted by address
+func newStateV4(resources []terraformutils.Resource, lineage string) stateV4 {
+	state := stateV4{
+		Version:          4,
+		TerraformVersion: "0.13.0",
+		Serial:           1,
+		Lineage:          lineage,
+		Outputs:          map[string]interface{}{},
+		Resources:        []stateV4Resource{},
+	}
+	for _, r := range resources {
+		provider := datadogProviderAddress
+		if alias, ok := r.Item["provider"].(string); ok && strings.HasPrefix(alias, "datadog.") {
+			provider += strings.TrimPrefix(alias, "datadog")
+		}
+		attributes := map[string]string{}
+		for key, value := range r.InstanceState.Attributes {
+			attributes[key] = value
+		}
+		attributes["id"] = r.InstanceState.ID
+		state.Resources = append(state.Resources, stateV4Resource{
+			Mode:      "managed",
+			Type:      r.InstanceInfo.Type,
+			Name:      r.ResourceName,
+			Provider:  provider,
+			Instances: []stateV4Instance{{AttributesFlat: attributes}},
+		})
+	}
+	sort.Slice(state.Resources, func(i, j int) bool {
+		if state.Resources[i].Type != state.Resources[j].Type {
+			return state.Resources[i].Type < state.Resources[j].Type
+		}
+		return state.Resources[i].Name < state.Resources[j].Name
+	})
+	return state
+}
+
+// newLineage return a random lineage, formatted as an UUID
+func newLineage() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// writeStateV4 write terraform.v4.tfstate, a state of the resources exported
+// to path readable by Terraform 0.13+ without terraform import or provider
+// replacement
+func writeStateV4(path string, resources []terraformutils.Resource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+	lineage, err := newLineage()
+	if err != nil {
+		return err
+	}
+	state, err := json.MarshalIndent(newStateV4(resources, lineage), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/terraform.v4.tfstate", append(state, '\n'), os.ModePerm)
+}
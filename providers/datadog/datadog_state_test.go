@@ -0,0 +1,56 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestStateV4(t *testing.T) {
+	monitor := terraformutils.NewResource("12345", "monitor_12345", "datadog_monitor", "datadog", map[string]string{
+		"id":   "12345",
+		"name": "CPU usage",
+		"type": "metric alert",
+	}, MonitorAllowEmptyValues, map[string]interface{}{})
+	monitor.Item = map[string]interface{}{"name": "CPU usage", "provider": "datadog.eu"}
+
+	path := t.TempDir()
+	if err := writeStateV4(path, []terraformutils.Resource{monitor}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(path + "/terraform.v4.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state stateV4
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	if state.Version != 4 || state.Lineage == "" || len(state.Resources) != 1 {
+		t.Fatalf("unexpected state %s", data)
+	}
+	r := state.Resources[0]
+	if r.Type != "datadog_monitor" || r.Name != "tfer--monitor_12345" || r.Provider != `provider["registry.terraform.io/datadog/datadog"].eu` {
+		t.Errorf("unexpected resource %+v", r)
+	}
+	if len(r.Instances) != 1 || r.Instances[0].AttributesFlat["id"] != "12345" || r.Instances[0].AttributesFlat["name"] != "CPU usage" {
+		t.Errorf("unexpected instances %+v", r.Instances)
+	}
+}
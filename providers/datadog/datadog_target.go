@@ -0,0 +1,57 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	dashboardURLPath = regexp.MustCompile(`^/dashboard/([a-z0-9]{3}-[a-z0-9]{3}-[a-z0-9]{3})(/|$)`)
+	monitorURLPath   = regexp.MustCompile(`^/monitors/([0-9]+)(/|$)`)
+	monitorURLHash   = regexp.MustCompile(`^/?([0-9]+)(/|$)`)
+)
+
+// parseTargetURL return the filter selecting the dashboard or the monitor
+// displayed at rawURL, a Datadog UI url
+func parseTargetURL(rawURL string) (*terraformutils.ResourceFilter, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, err
+	}
+
+	service, id := "", ""
+	if match := dashboardURLPath.FindStringSubmatch(u.Path); match != nil {
+		service, id = "dashboard", match[1]
+	} else if match := monitorURLPath.FindStringSubmatch(u.Path); match != nil {
+		service, id = "monitor", match[1]
+	} else if match := monitorURLHash.FindStringSubmatch(u.Fragment); u.Path == "/monitors" && match != nil {
+		// Legacy monitor urls: /monitors#12345/edit
+		service, id = "monitor", match[1]
+	}
+	if service == "" {
+		return nil, fmt.Errorf("%s is neither a dashboard nor a monitor url", rawURL)
+	}
+	return &terraformutils.ResourceFilter{
+		ServiceName:      service,
+		FieldPath:        "id",
+		AcceptableValues: []string{id},
+	}, nil
+}
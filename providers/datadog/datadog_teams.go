@@ -0,0 +1,86 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var unsafeTeamPathChars = regexp.MustCompile(`[^0-9A-Za-z_.-]+`)
+
+// tagPathSegment return the directory name of a tag value, false when the
+// value names no directory of its own, as "." or ".." would escape it
+func tagPathSegment(value string) (string, bool) {
+	segment := unsafeTeamPathChars.ReplaceAllString(value, "_")
+	if strings.Trim(segment, ".") == "" {
+		return "", false
+	}
+	return segment, true
+}
+
+// resourceTagValue return the value of the first tagKey: tag of the resource
+func resourceTagValue(r terraformutils.Resource, tagKey string) string {
+	var values []string
+	for key, tag := range r.InstanceState.Attributes {
+		if !strings.HasPrefix(key, "tags.") || key == "tags.#" {
+			continue
+		}
+		if value := strings.TrimPrefix(tag, tagKey+":"); value != tag && value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	sort.Strings(values)
+	return values[0]
+}
+
+// resourceTeam return the value of the first team: tag of the resource
+func resourceTeam(r terraformutils.Resource) string {
+	return resourceTagValue(r, "team")
+}
+
+// groupByTag split resources into one directory under path per value of their
+// tagKey: tag, resources without such tag stay in path
+func groupByTag(path, tagKey string, resources []terraformutils.Resource) map[string][]terraformutils.Resource {
+	groups := map[string][]terraformutils.Resource{}
+	for _, r := range resources {
+		tagPath := path
+		if value := resourceTagValue(r, tagKey); value != "" {
+			segment, ok := tagPathSegment(value)
+			if !ok {
+				log.Printf("%s %s: %s:%s is not a directory name, keeping it in %s", r.InstanceInfo.Type, r.InstanceState.ID, tagKey, value, path)
+			} else {
+				// Keep the trailing separator of the service path, its slashes
+				// give the depth of the remote states of a connected export
+				tagPath = strings.TrimSuffix(path, "/") + "/" + segment + "/"
+			}
+		}
+		groups[tagPath] = append(groups[tagPath], r)
+	}
+	return groups
+}
+
+// groupByTeam split resources into one directory under path per team: tag,
+// resources without team tag stay in path
+func groupByTeam(path string, resources []terraformutils.Resource) map[string][]terraformutils.Resource {
+	return groupByTag(path, "team", resources)
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestSplitPathByTeam(t *testing.T) {
+	newMonitor := func(id string, tags ...string) terraformutils.Resource {
+		attributes := map[string]string{"id": id}
+		for i, tag := range tags {
+			attributes["tags."+string(rune('0'+i))] = tag
+		}
+		return terraformutils.NewResource(id, "monitor_"+id, "datadog_monitor", "datadog", attributes, MonitorAllowEmptyValues, map[string]interface{}{})
+	}
+	resources := []terraformutils.Resource{
+		newMonitor("1", "env:prod", "team:core"),
+		newMonitor("2", "team:payments"),
+		newMonitor("3", "env:prod"),
+		newMonitor("4", "team:.."),
+		newMonitor("5", "team:../../etc"),
+	}
+
+	path := "generated/datadog/monitor/"
+	provider := &DatadogProvider{}
+	if groups := provider.SplitPath(path, resources); !reflect.DeepEqual(groups, map[string][]terraformutils.Resource{path: resources}) {
+		t.Errorf("resources must not be split unless grouping by team, got %v", groups)
+	}
+
+	provider.groupByTeam = true
+	groups := provider.SplitPath(path, resources)
+	expected := map[string][]string{
+		path + "core/":      {"1"},
+		path + "payments/":  {"2"},
+		path:                {"3", "4"},
+		path + ".._.._etc/": {"5"},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d roots, got %v", len(expected), groups)
+	}
+	for root, ids := range expected {
+		var found []string
+		for _, r := range groups[root] {
+			found = append(found, r.InstanceState.ID)
+		}
+		if !reflect.DeepEqual(found, ids) {
+			t.Errorf("expected monitors %v in %s, got %v", ids, root, found)
+		}
+	}
+}
+
+func TestSplitPathByEnv(t *testing.T) {
+	newMonitor := func(id string, tags ...string) terraformutils.Resource {
+		attributes := map[string]string{"id": id}
+		for i, tag := range tags {
+			attributes["tags."+string(rune('0'+i))] = tag
+		}
+		return terraformutils.NewResource(id, "monitor_"+id, "datadog_monitor", "datadog", attributes, MonitorAllowEmptyValues, map[string]interface{}{})
+	}
+	resources := []terraformutils.Resource{
+		newMonitor("1", "env:prod", "team:core"),
+		newMonitor("2", "env:staging"),
+		newMonitor("3", "env:prod"),
+		newMonitor("4", "env:."),
+	}
+
+	path := "generated/datadog/monitor/"
+	provider := &DatadogProvider{groupByEnv: true}
+	groups := provider.SplitPath(path, resources)
+	expected := map[string][]string{
+		path + "prod/":    {"1", "3"},
+		path + "staging/": {"2"},
+		path:              {"4"},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d workspaces, got %v", len(expected), groups)
+	}
+	for root, ids := range expected {
+		var found []string
+		for _, r := range groups[root] {
+			found = append(found, r.InstanceState.ID)
+		}
+		if !reflect.DeepEqual(found, ids) {
+			t.Errorf("expected monitors %v in %s, got %v", ids, root, found)
+		}
+	}
+
+	provider.groupByTeam = true
+	groups = provider.SplitPath(path, resources)
+	if len(groups[path+"prod/core/"]) != 1 || len(groups[path+"prod/"]) != 1 {
+		t.Errorf("expected the prod workspace to be split by team, got %v", groups)
+	}
+}
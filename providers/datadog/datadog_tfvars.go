@@ -0,0 +1,118 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+// tfvarsField select the attribute of a resource type to extract as a variable
+type tfvarsField struct {
+	resourceType string
+	path         string
+}
+
+// parseTfvarsFields parse a comma separated list of `<resource_type>:<attribute.path>`
+func parseTfvarsFields(value string) ([]tfvarsField, error) {
+	var fields []tfvarsField
+	for _, selector := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(selector), ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf(`invalid field selector %q, expected <resource_type>:<attribute.path>`, selector)
+		}
+		fields = append(fields, tfvarsField{resourceType: parts[0], path: parts[1]})
+	}
+	return fields, nil
+}
+
+// extractTfvars replace the selected attributes of resources by variable
+// references and return the extracted values by variable name
+func extractTfvars(resources []terraformutils.Resource, fields []tfvarsField) map[string]interface{} {
+	variables := map[string]interface{}{}
+	for _, r := range resources {
+		for _, field := range fields {
+			if field.resourceType != r.InstanceInfo.Type {
+				continue
+			}
+			name := strings.TrimPrefix(r.ResourceName, "tfer--") + "_" + strings.ReplaceAll(field.path, ".", "_")
+			if value, ok := replaceItemValue(r.Item, strings.Split(field.path, "."), "${var."+name+"}"); ok {
+				variables[name] = value
+			}
+		}
+	}
+	return variables
+}
+
+// replaceItemValue walk item along path, going through single element blocks,
+// replace the value found with newValue and return the previous one
+func replaceItemValue(item interface{}, path []string, newValue string) (interface{}, bool) {
+	switch v := item.(type) {
+	case []interface{}:
+		if len(v) != 1 {
+			return nil, false
+		}
+		return replaceItemValue(v[0], path, newValue)
+	case map[string]interface{}:
+		value, exist := v[path[0]]
+		if !exist {
+			return nil, false
+		}
+		if len(path) == 1 {
+			v[path[0]] = newValue
+			return value, true
+		}
+		return replaceItemValue(value, path[1:], newValue)
+	}
+	return nil, false
+}
+
+// writeTfvarsSeed write the variable declarations and a terraform.tfvars
+// holding the extracted values to path
+func writeTfvarsSeed(path, output string, variables map[string]interface{}) error {
+	if len(variables) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	declarations := map[string]interface{}{}
+	for name := range variables {
+		declarations[name] = map[string]interface{}{}
+	}
+	variablesFile, err := terraformutils.Print(map[string]interface{}{"variable": declarations}, map[string]struct{}{}, output)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+"/tfvars_variables."+terraformoutput.GetFileExtension(output), variablesFile, os.ModePerm); err != nil {
+		return err
+	}
+
+	tfvarsFile, err := terraformutils.Print(variables, map[string]struct{}{}, output)
+	if err != nil {
+		return err
+	}
+	tfvarsFileName := "terraform.tfvars"
+	if output == "json" {
+		tfvarsFileName += ".json"
+	}
+	return ioutil.WriteFile(path+"/"+tfvarsFileName, tfvarsFile, os.ModePerm)
+}
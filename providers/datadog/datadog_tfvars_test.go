@@ -0,0 +1,73 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestTfvarsSeed(t *testing.T) {
+	fields, err := parseTfvarsFields("datadog_monitor:monitor_thresholds.critical, datadog_monitor:monitor_thresholds.warning")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource := terraformutils.NewSimpleResource("1", "monitor_1", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	resource.Item = map[string]interface{}{
+		"name": "cpu",
+		"monitor_thresholds": []interface{}{map[string]interface{}{
+			"critical": "90",
+			"warning":  "80",
+		}},
+	}
+
+	variables := extractTfvars([]terraformutils.Resource{resource}, fields)
+	if variables["monitor_1_monitor_thresholds_critical"] != "90" || variables["monitor_1_monitor_thresholds_warning"] != "80" {
+		t.Errorf("thresholds were not extracted: %v", variables)
+	}
+	thresholds := resource.Item["monitor_thresholds"].([]interface{})[0].(map[string]interface{})
+	if thresholds["critical"] != "${var.monitor_1_monitor_thresholds_critical}" {
+		t.Errorf("critical threshold does not reference its variable: %v", thresholds)
+	}
+
+	path := t.TempDir()
+	if err := writeTfvarsSeed(path, "hcl", variables); err != nil {
+		t.Fatal(err)
+	}
+	tfvars, err := ioutil.ReadFile(path + "/terraform.tfvars")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(tfvars), `monitor_1_monitor_thresholds_critical = "90"`) {
+		t.Errorf("unexpected terraform.tfvars:\n%s", tfvars)
+	}
+	declarations, err := ioutil.ReadFile(path + "/tfvars_variables.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(declarations), `variable "monitor_1_monitor_thresholds_critical"`) {
+		t.Errorf("unexpected variable declarations:\n%s", declarations)
+	}
+}
+
+func TestParseTfvarsFieldsInvalid(t *testing.T) {
+	if _, err := parseTfvarsFields("monitor_thresholds.critical"); err == nil {
+		t.Error("expected selector without resource type to be rejected")
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// validateUniqueImportIDs return an error naming the offending resources when
+// two resources share the same type and import id, as importing them would
+// make one overwrite the other in state
+func validateUniqueImportIDs(importedResource map[string][]terraformutils.Resource) error {
+	services := make([]string, 0, len(importedResource))
+	for service := range importedResource {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	seen := map[string]string{}
+	var duplicates []string
+	for _, service := range services {
+		for _, r := range importedResource[service] {
+			key := r.InstanceInfo.Type + " " + r.InstanceState.ID
+			address := fmt.Sprintf("%s.%s (service %s)", r.InstanceInfo.Type, r.ResourceName, service)
+			if previous, exist := seen[key]; exist {
+				duplicates = append(duplicates, fmt.Sprintf("%s id %q: %s and %s", r.InstanceInfo.Type, r.InstanceState.ID, previous, address))
+				continue
+			}
+			seen[key] = address
+		}
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("duplicate import ids found:\n%s", strings.Join(duplicates, "\n"))
+	}
+	return nil
+}
+
+// metricComputeResourceTypes list the generated metrics resource types holding a compute block
+var metricComputeResourceTypes = map[string]struct{}{
+	"datadog_logs_metric":  {},
+	"datadog_spans_metric": {},
+}
+
+// mapMetricComputes rebuild the compute block of the generated metrics from
+// their state so every aggregation type is exported with only the fields it
+// supports, and return an error listing the invalid ones
+func mapMetricComputes(importedResource map[string][]terraformutils.Resource) error {
+	var invalid []string
+	for _, resources := range importedResource {
+		for i, r := range resources {
+			if _, ok := metricComputeResourceTypes[r.InstanceInfo.Type]; !ok {
+				continue
+			}
+			compute, err := metricCompute(r.InstanceState.Attributes)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("%s.%s: %v", r.InstanceInfo.Type, r.ResourceName, err))
+				continue
+			}
+			resources[i].Item["compute"] = []interface{}{compute}
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("invalid metric compute found:\n%s", strings.Join(invalid, "\n"))
+	}
+	return nil
+}
+
+// metricCompute return the compute block of a logs or spans metric state.
+// A count counts the matching events, a distribution aggregates the value at
+// path and may include percentiles.
+func metricCompute(attributes map[string]string) (map[string]interface{}, error) {
+	aggregationType := attributes["compute.0.aggregation_type"]
+	path := attributes["compute.0.path"]
+	includePercentiles := attributes["compute.0.include_percentiles"]
+
+	switch aggregationType {
+	case "count":
+		if path != "" {
+			return nil, fmt.Errorf("count aggregation does not support path %q", path)
+		}
+		if includePercentiles == "true" {
+			return nil, fmt.Errorf("count aggregation does not support percentiles")
+		}
+		return map[string]interface{}{"aggregation_type": aggregationType}, nil
+	case "distribution":
+		if path == "" {
+			return nil, fmt.Errorf("distribution aggregation requires a path")
+		}
+		compute := map[string]interface{}{"aggregation_type": aggregationType, "path": path}
+		if includePercentiles != "" {
+			compute["include_percentiles"] = includePercentiles
+		}
+		return compute, nil
+	default:
+		return nil, fmt.Errorf("unknown aggregation type %q", aggregationType)
+	}
+}
+
+// queryAttributes list the query attributes validated offline, by resource type
+var queryAttributes = map[string][]string{
+	"datadog_monitor":                 {"query"},
+	"datadog_service_level_objective": {"query.0.numerator", "query.0.denominator"},
+}
+
+// queryWarnings check the queries of the imported monitors and SLOs against
+// a basic grammar and return a warning for each one obviously malformed
+func queryWarnings(importedResource map[string][]terraformutils.Resource) []string {
+	var warnings []string
+	for _, resources := range importedResource {
+		for _, r := range resources {
+			for _, attribute := range queryAttributes[r.InstanceInfo.Type] {
+				query, ok := r.InstanceState.Attributes[attribute]
+				if !ok {
+					continue
+				}
+				if err := validateQuerySyntax(query); err != nil {
+					warnings = append(warnings, fmt.Sprintf("%s.%s %s: %v", r.InstanceInfo.Type, r.ResourceName, attribute, err))
+				}
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// validateQuerySyntax return an error if query is empty, or if its brackets
+// or quotes are unbalanced
+func validateQuerySyntax(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("empty query")
+	}
+	closing := map[rune]rune{')': '(', '}': '{', ']': '['}
+	var stack []rune
+	var quote rune
+	escaped := false
+	for i, c := range query {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '{' || c == '[':
+			stack = append(stack, c)
+		case closing[c] != 0:
+			if len(stack) == 0 || stack[len(stack)-1] != closing[c] {
+				return fmt.Errorf("unexpected %q at offset %d", c, i)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if quote != 0 {
+		return fmt.Errorf("unterminated %q quote", quote)
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
+
+// monitorQueryPrefixes is the prefix of the query required by each monitor
+// type, "" when the type only requires a query
+var monitorQueryPrefixes = map[string][]string{
+	"audit alert":           {"audit("},
+	"composite":             {""},
+	"event-v2 alert":        {"events("},
+	"log alert":             {"logs("},
+	"metric alert":          {""},
+	"process alert":         {"processes("},
+	"query alert":           {""},
+	"rum alert":             {"rum("},
+	"service check":         {""},
+	"slo alert":             {"error_budget(", "burn_rate("},
+	"trace-analytics alert": {"trace-analytics(", "spans("},
+}
+
+// monitorWarnings return a warning for each imported monitor missing a field
+// required by its type, which the mapping failed to carry over
+func monitorWarnings(importedResource map[string][]terraformutils.Resource) []string {
+	var warnings []string
+	for _, r := range importedResource["monitor"] {
+		if r.InstanceInfo.Type != "datadog_monitor" {
+			continue
+		}
+		address := r.InstanceInfo.Type + "." + r.ResourceName
+		attributes := r.InstanceState.Attributes
+		if attributes["name"] == "" {
+			warnings = append(warnings, fmt.Sprintf("%s is missing its name", address))
+		}
+		monitorType := attributes["type"]
+		prefixes, ok := monitorQueryPrefixes[monitorType]
+		if !ok {
+			continue
+		}
+		query := strings.TrimSpace(attributes["query"])
+		if query == "" {
+			warnings = append(warnings, fmt.Sprintf("%s %s is missing its query", address, monitorType))
+			continue
+		}
+		matched := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(query, prefix) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			warnings = append(warnings, fmt.Sprintf("%s %s query must start with %s", address, monitorType, strings.Join(prefixes, " or ")))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// tagPolicyWarnings return a warning for each imported monitor violating an
+// imported monitor config tag policy: missing a required tag key, or tagged
+// with a value the policy doesn't allow
+func tagPolicyWarnings(importedResource map[string][]terraformutils.Resource) []string {
+	var warnings []string
+	for _, policy := range importedResource["monitor_config_policy"] {
+		attributes := policy.InstanceState.Attributes
+		tagKey := attributes["tag_policy.0.tag_key"]
+		if attributes["policy_type"] != "tag" || tagKey == "" {
+			continue
+		}
+		validValues := map[string]bool{}
+		for key, value := range attributes {
+			if strings.HasPrefix(key, "tag_policy.0.valid_tag_values.") && key != "tag_policy.0.valid_tag_values.#" {
+				validValues[value] = true
+			}
+		}
+
+		for _, monitor := range importedResource["monitor"] {
+			if monitor.InstanceInfo.Type != "datadog_monitor" {
+				continue
+			}
+			address := monitor.InstanceInfo.Type + "." + monitor.ResourceName
+			value := resourceTagValue(monitor, tagKey)
+			switch {
+			case value == "" && attributes["tag_policy.0.tag_key_required"] == "true":
+				warnings = append(warnings, fmt.Sprintf("%s is missing the tag key %s required by %s.%s", address, tagKey, policy.InstanceInfo.Type, policy.ResourceName))
+			case value != "" && len(validValues) > 0 && !validValues[value]:
+				warnings = append(warnings, fmt.Sprintf("%s tag %s:%s is not allowed by %s.%s", address, tagKey, value, policy.InstanceInfo.Type, policy.ResourceName))
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// privateLocationWarnings return a warning for each private location an
+// imported synthetics test runs from which isn't imported, the test would
+// reference a location terraform doesn't manage
+func privateLocationWarnings(importedResource map[string][]terraformutils.Resource) []string {
+	imported := map[string]bool{}
+	for _, location := range importedResource["synthetics_private_location"] {
+		imported[location.InstanceState.ID] = true
+	}
+	var warnings []string
+	for _, r := range importedResource["synthetics"] {
+		if r.InstanceInfo.Type != "datadog_synthetics_test" {
+			continue
+		}
+		for key, location := range r.InstanceState.Attributes {
+			if !strings.HasPrefix(key, "locations.") || key == "locations.#" {
+				continue
+			}
+			if strings.HasPrefix(location, "pl:") && !imported[location] {
+				warnings = append(warnings, fmt.Sprintf("%s.%s private location %s is not imported", r.InstanceInfo.Type, r.ResourceName, location))
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
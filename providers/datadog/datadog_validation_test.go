@@ -0,0 +1,199 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestValidateUniqueImportIDs(t *testing.T) {
+	importedResource := map[string][]terraformutils.Resource{
+		"monitor": {
+			terraformutils.NewSimpleResource("1", "monitor_1", "datadog_monitor", "datadog", MonitorAllowEmptyValues),
+			terraformutils.NewSimpleResource("1", "monitor_1_copy", "datadog_monitor", "datadog", MonitorAllowEmptyValues),
+		},
+		"dashboard": {
+			terraformutils.NewSimpleResource("1", "dashboard_1", "datadog_dashboard", "datadog", DashboardAllowEmptyValues),
+		},
+	}
+
+	err := validateUniqueImportIDs(importedResource)
+	if err == nil {
+		t.Fatal("expected duplicate import ids to be reported")
+	}
+	for _, name := range []string{"datadog_monitor.tfer--monitor_1 ", "datadog_monitor.tfer--monitor_1_copy "} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected error to name %s, got %s", name, err)
+		}
+	}
+	if strings.Contains(err.Error(), "datadog_dashboard") {
+		t.Errorf("resources of different types must not be reported: %s", err)
+	}
+
+	delete(importedResource, "monitor")
+	if err := validateUniqueImportIDs(importedResource); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+}
+
+func TestMapMetricComputes(t *testing.T) {
+	newMetric := func(resourceType, name string, attributes map[string]string) terraformutils.Resource {
+		r := terraformutils.NewResource(name, name, resourceType, "datadog", attributes, []string{}, map[string]interface{}{})
+		r.Item = map[string]interface{}{"name": name}
+		return r
+	}
+	count := newMetric("datadog_logs_metric", "logs_count", map[string]string{
+		"compute.#":                  "1",
+		"compute.0.aggregation_type": "count",
+	})
+	distribution := newMetric("datadog_spans_metric", "spans_duration", map[string]string{
+		"compute.#":                     "1",
+		"compute.0.aggregation_type":    "distribution",
+		"compute.0.path":                "@duration",
+		"compute.0.include_percentiles": "true",
+	})
+	importedResource := map[string][]terraformutils.Resource{
+		"logs_metric":  {count},
+		"spans_metric": {distribution},
+	}
+
+	if err := mapMetricComputes(importedResource); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{map[string]interface{}{"aggregation_type": "count"}}
+	if compute := importedResource["logs_metric"][0].Item["compute"]; !reflect.DeepEqual(compute, expected) {
+		t.Errorf("expected count compute %v, got %v", expected, compute)
+	}
+	expected = []interface{}{map[string]interface{}{"aggregation_type": "distribution", "path": "@duration", "include_percentiles": "true"}}
+	if compute := importedResource["spans_metric"][0].Item["compute"]; !reflect.DeepEqual(compute, expected) {
+		t.Errorf("expected distribution compute %v, got %v", expected, compute)
+	}
+
+	for name, attributes := range map[string]map[string]string{
+		"count with path":           {"compute.0.aggregation_type": "count", "compute.0.path": "@duration"},
+		"count with percentiles":    {"compute.0.aggregation_type": "count", "compute.0.include_percentiles": "true"},
+		"distribution without path": {"compute.0.aggregation_type": "distribution"},
+		"unknown aggregation type":  {"compute.0.aggregation_type": "gauge"},
+	} {
+		importedResource := map[string][]terraformutils.Resource{
+			"logs_metric": {newMetric("datadog_logs_metric", "invalid", attributes)},
+		}
+		if err := mapMetricComputes(importedResource); err == nil {
+			t.Errorf("expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestQueryWarnings(t *testing.T) {
+	newMonitor := func(name, query string) terraformutils.Resource {
+		return terraformutils.NewResource(name, name, "datadog_monitor", "datadog", map[string]string{"query": query}, MonitorAllowEmptyValues, map[string]interface{}{})
+	}
+	importedResource := map[string][]terraformutils.Resource{
+		"monitor": {
+			newMonitor("valid", `avg(last_5m):avg:system.cpu.user{env:prod,service:"web(api)"} by {host} > 90`),
+			newMonitor("unbalanced", `avg(last_5m:avg:system.cpu.user{env:prod} > 90`),
+		},
+	}
+
+	warnings := queryWarnings(importedResource)
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "datadog_monitor.tfer--unbalanced query:") {
+		t.Errorf("expected a single warning for the unbalanced query, got %v", warnings)
+	}
+}
+
+func TestMonitorWarnings(t *testing.T) {
+	newMonitor := func(id, monitorType, query string) terraformutils.Resource {
+		return terraformutils.NewResource(id, "monitor_"+id, "datadog_monitor", "datadog", map[string]string{
+			"id":    id,
+			"name":  "monitor " + id,
+			"type":  monitorType,
+			"query": query,
+		}, MonitorAllowEmptyValues, map[string]interface{}{})
+	}
+
+	warnings := monitorWarnings(map[string][]terraformutils.Resource{
+		"monitor": {
+			newMonitor("1", "log alert", ""),
+			newMonitor("2", "log alert", `logs("service:web status:error").index("*").rollup("count").last("5m") > 10`),
+			newMonitor("3", "slo alert", `error_budget("abc").over("7d") > 10`),
+		},
+	})
+
+	expected := []string{"datadog_monitor.tfer--monitor_1 log alert is missing its query"}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+}
+
+func TestTagPolicyWarnings(t *testing.T) {
+	policy := terraformutils.NewResource("policy-uuid", "monitor_config_policy_team", "datadog_monitor_config_policy", "datadog", map[string]string{
+		"id":                              "policy-uuid",
+		"policy_type":                     "tag",
+		"tag_policy.#":                    "1",
+		"tag_policy.0.tag_key":            "team",
+		"tag_policy.0.tag_key_required":   "true",
+		"tag_policy.0.valid_tag_values.#": "2",
+		"tag_policy.0.valid_tag_values.0": "core",
+		"tag_policy.0.valid_tag_values.1": "payments",
+	}, []string{}, map[string]interface{}{})
+	newMonitor := func(id string, tags ...string) terraformutils.Resource {
+		attributes := map[string]string{"id": id}
+		for i, tag := range tags {
+			attributes["tags."+string(rune('0'+i))] = tag
+		}
+		return terraformutils.NewResource(id, "monitor_"+id, "datadog_monitor", "datadog", attributes, MonitorAllowEmptyValues, map[string]interface{}{})
+	}
+
+	warnings := tagPolicyWarnings(map[string][]terraformutils.Resource{
+		"monitor_config_policy": {policy},
+		"monitor": {
+			newMonitor("1", "team:core"),
+			newMonitor("2", "env:prod"),
+			newMonitor("3", "team:unknown"),
+		},
+	})
+
+	expected := []string{
+		"datadog_monitor.tfer--monitor_2 is missing the tag key team required by datadog_monitor_config_policy.tfer--monitor_config_policy_team",
+		"datadog_monitor.tfer--monitor_3 tag team:unknown is not allowed by datadog_monitor_config_policy.tfer--monitor_config_policy_team",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+}
+
+func TestPrivateLocationWarnings(t *testing.T) {
+	location := terraformutils.NewSimpleResource("pl:imported-123", "synthetics_private_location_pl:imported-123", "datadog_synthetics_private_location", "datadog", []string{})
+	test := terraformutils.NewResource("abc-def-ghi", "synthetics_abc-def-ghi", "datadog_synthetics_test", "datadog", map[string]string{
+		"id":          "abc-def-ghi",
+		"locations.#": "3",
+		"locations.0": "aws:us-east-1",
+		"locations.1": "pl:imported-123",
+		"locations.2": "pl:missing-456",
+	}, []string{}, map[string]interface{}{})
+
+	warnings := privateLocationWarnings(map[string][]terraformutils.Resource{
+		"synthetics":                  {test},
+		"synthetics_private_location": {location},
+	})
+	expected := []string{"datadog_synthetics_test." + test.ResourceName + " private location pl:missing-456 is not imported"}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// attributeProviderVersions is the datadog provider version which introduced
+// an attribute, for the attributes added after the 3.0.0 release
+var attributeProviderVersions = map[string]map[string]string{
+	"datadog_dashboard": {
+		"restricted_roles": "3.33.0",
+	},
+	"datadog_monitor": {
+		"on_missing_data":          "3.31.0",
+		"restricted_roles":         "3.16.0",
+		"scheduling_options":       "3.22.0",
+		"notification_preset_name": "3.36.0",
+	},
+	"datadog_security_monitoring_rule": {
+		"has_extended_title": "3.10.0",
+		"reference_tables":   "3.41.0",
+	},
+	"datadog_service_level_objective": {
+		"sli_specification": "3.37.0",
+	},
+}
+
+// minimumProviderVersion return the oldest datadog provider version supporting
+// all the attributes set on the resource, "" if any 3.x version does
+func minimumProviderVersion(r terraformutils.Resource) string {
+	minimum := ""
+	for attribute, version := range attributeProviderVersions[r.InstanceInfo.Type] {
+		if value, ok := r.Item[attribute]; !ok || value == nil {
+			continue
+		}
+		if minimum == "" || compareVersions(version, minimum) > 0 {
+			minimum = version
+		}
+	}
+	return minimum
+}
+
+// providerVersionComment return the comment noting the datadog provider version
+// the resource requires, "" if it doesn't require a recent one
+func providerVersionComment(r terraformutils.Resource) string {
+	version := minimumProviderVersion(r)
+	if version == "" {
+		return ""
+	}
+	return fmt.Sprintf("requires datadog provider >= %s", version)
+}
+
+// compareVersions compare two x.y.z versions, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
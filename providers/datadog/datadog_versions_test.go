@@ -0,0 +1,46 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestProviderVersionComment(t *testing.T) {
+	restricted := terraformutils.NewSimpleResource("1", "monitor_1", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	restricted.Item = map[string]interface{}{
+		"name":             "restricted",
+		"restricted_roles": []interface{}{"role-uuid"},
+		"on_missing_data":  "show_and_notify_no_data",
+	}
+	plain := terraformutils.NewSimpleResource("2", "monitor_2", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	plain.Item = map[string]interface{}{"name": "plain"}
+
+	g := &MonitorGenerator{}
+	g.SetArgs(map[string]interface{}{"annotate-provider-versions": true})
+	g.Resources = []terraformutils.Resource{restricted, plain}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	if comment := g.Resources[0].Item["//"]; comment != "requires datadog provider >= 3.31.0" {
+		t.Errorf("unexpected comment %v", comment)
+	}
+	if comment, ok := g.Resources[1].Item["//"]; ok {
+		t.Errorf("expected no comment on a monitor using no recent attribute, got %v", comment)
+	}
+}
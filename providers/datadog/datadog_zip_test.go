@@ -0,0 +1,73 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWriteOutputZip(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "datadog")
+	for name, content := range map[string]string{
+		"monitor/monitor.tf":                        `resource "datadog_monitor" "tfer--monitor_123" {}`,
+		"monitor/provider.tf":                       `provider "datadog" {}`,
+		"monitor/terraform.tfstate":                 `{"version": 3}`,
+		"webhook/secrets.auto.tfvars.example":       `webhook_url = ""`,
+		"service_level_objective/outputs.tf":        `output "id" {}`,
+		"service_level_objective/terraform.tfstate": `{"version": 3}`,
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the archive written under the output path isn't added to itself
+	zipPath := filepath.Join(root, "datadog.zip")
+	if err := writeOutputZip(root, zipPath); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+	expected := []string{
+		"monitor/monitor.tf",
+		"monitor/provider.tf",
+		"monitor/terraform.tfstate",
+		"service_level_objective/outputs.tf",
+		"service_level_objective/terraform.tfstate",
+		"webhook/secrets.auto.tfvars.example",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the zip to contain %v, got %v", expected, names)
+	}
+}
@@ -17,6 +17,10 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
@@ -26,18 +30,62 @@ import (
 var (
 	// IntegrationAWSAllowEmptyValues ...
 	IntegrationAWSAllowEmptyValues = []string{}
+
+	awsAccountID   = regexp.MustCompile(`^[0-9]{12}$`)
+	awsIAMRoleName = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
+
+	// deprecatedAWSNamespaces map the namespace rule keys the AWS integration
+	// still accepts, but no longer lists, to their current key
+	deprecatedAWSNamespaces = map[string]string{
+		"cloudwatch_events": "events",
+		"cloudwatch_logs":   "logs",
+		"elasticsearch":     "es",
+		"elasticmapreduce":  "emr",
+		"simpleworkflow":    "swf",
+	}
 )
 
+// awsIntegrationAccount is an AWS account with the account level collection
+// settings the API client doesn't know of
+type awsIntegrationAccount struct {
+	datadogV1.AWSAccount
+	MetricsCollectionEnabled      *bool `json:"metrics_collection_enabled"`
+	ResourceCollectionEnabled     *bool `json:"resource_collection_enabled"`
+	CSPMResourceCollectionEnabled *bool `json:"cspm_resource_collection_enabled"`
+}
+
+type awsIntegrationAccountsResponse struct {
+	Accounts []awsIntegrationAccount `json:"accounts"`
+}
+
 // IntegrationAWSGenerator ...
 type IntegrationAWSGenerator struct {
 	DatadogService
+	namespaces map[string]bool
 }
 
-func (g *IntegrationAWSGenerator) createResources(awsAccounts []datadogV1.AWSAccount) []terraformutils.Resource {
+func (g *IntegrationAWSGenerator) createResources(awsAccounts []awsIntegrationAccount) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, account := range awsAccounts {
 		resourceID := fmt.Sprintf("%s:%s", account.GetAccountId(), account.GetRoleName())
-		resources = append(resources, g.createResource(resourceID))
+		resource := g.createResource(resourceID)
+		for field, enabled := range map[string]*bool{
+			"metrics_collection_enabled":       account.MetricsCollectionEnabled,
+			"resource_collection_enabled":      account.ResourceCollectionEnabled,
+			"cspm_resource_collection_enabled": account.CSPMResourceCollectionEnabled,
+		} {
+			if enabled != nil {
+				resource.AdditionalFields[field] = *enabled
+			}
+		}
+		if account.HasAccountSpecificNamespaceRules() {
+			rules := map[string]interface{}{}
+			for namespace, enabled := range account.GetAccountSpecificNamespaceRules() {
+				rules[namespace] = enabled
+			}
+			resource.AdditionalFields["account_specific_namespace_rules"] = rules
+		}
+		resources = append(resources, resource)
 	}
 
 	return resources
@@ -54,16 +102,102 @@ func (g *IntegrationAWSGenerator) createResource(resourceID string) terraformuti
 }
 
 // InitResources Generate TerraformResources from Datadog API,
-// from each monitor create 1 TerraformResource.
+// from each AWS account create 1 TerraformResource, the account level collection
+// settings (metrics, resources, CSPM and namespace rules) are mapped from the
+// API as the provider may not read them back.
 // Need IntegrationAWS ID formatted as '<account_id>:<role_name>' as ID for terraform resource
 func (g *IntegrationAWSGenerator) InitResources() error {
 	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
 	authV1 := g.Args["authV1"].(context.Context)
 
-	integrations, _, err := datadogClientV1.AWSIntegrationApi.ListAWSAccounts(authV1).Execute()
-	if err != nil {
+	var integrations awsIntegrationAccountsResponse
+	if err := getV1(datadogClientV1, authV1, "/api/v1/integration/aws", url.Values{}, &integrations); err != nil {
 		return err
 	}
-	g.Resources = g.createResources(integrations.GetAccounts())
+	if validate, ok := g.Args["validate-aws-accounts"].(bool); ok && validate {
+		accounts := make([]datadogV1.AWSAccount, 0, len(integrations.Accounts))
+		for _, account := range integrations.Accounts {
+			accounts = append(accounts, account.AWSAccount)
+		}
+		for _, warning := range awsAccountWarnings(accounts) {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+	if migrate, ok := g.Args["migrate-aws-namespaces"].(bool); ok && migrate {
+		namespaces, _, err := datadogClientV1.AWSIntegrationApi.ListAvailableAWSNamespaces(authV1).Execute()
+		if err != nil {
+			return err
+		}
+		g.namespaces = map[string]bool{}
+		for _, namespace := range namespaces {
+			g.namespaces[namespace] = true
+		}
+	}
+	g.Resources = g.createResources(integrations.Accounts)
 	return nil
 }
+
+// PostConvertHook rename the deprecated account_specific_namespace_rules keys
+// to their current key when the namespaces migration is enabled
+func (g *IntegrationAWSGenerator) PostConvertHook() error {
+	if g.namespaces != nil {
+		for _, r := range g.Resources {
+			rules, ok := r.Item["account_specific_namespace_rules"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, warning := range migrateAWSNamespaceRules(rules, g.namespaces) {
+				log.Printf("[WARN] AWS account %s: %s", r.InstanceState.ID, warning)
+			}
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
+
+// awsAccountWarnings return a warning for each account whose id is not a 12
+// digits AWS account id or whose role is not a valid IAM role name, such an
+// account can't be assumed and fails on the next apply. The GovCloud and China
+// accounts authenticate with an access key and have no account id to check.
+func awsAccountWarnings(awsAccounts []datadogV1.AWSAccount) []string {
+	var warnings []string
+	for _, account := range awsAccounts {
+		if account.GetAccessKeyId() == "" && !awsAccountID.MatchString(account.GetAccountId()) {
+			warnings = append(warnings, fmt.Sprintf("invalid AWS account id %q for role %q", account.GetAccountId(), account.GetRoleName()))
+		}
+		if account.HasRoleName() && !awsIAMRoleName.MatchString(account.GetRoleName()) {
+			warnings = append(warnings, fmt.Sprintf("invalid IAM role name %q for AWS account %q", account.GetRoleName(), account.GetAccountId()))
+		}
+	}
+	return warnings
+}
+
+// migrateAWSNamespaceRules rename in place the deprecated namespace keys of
+// rules to their current key, following deprecatedAWSNamespaces. It return a
+// warning for each key neither available nor mapped, which is kept as is, and
+// for each deprecated key whose current key is already set, which is dropped.
+func migrateAWSNamespaceRules(rules map[string]interface{}, namespaces map[string]bool) []string {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+	for _, key := range keys {
+		if namespaces[key] {
+			continue
+		}
+		current, ok := deprecatedAWSNamespaces[key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("namespace %q is not available and has no replacement, kept as is", key))
+			continue
+		}
+		if _, ok := rules[current]; ok {
+			warnings = append(warnings, fmt.Sprintf("deprecated namespace %q dropped, its replacement %q is already set", key, current))
+		} else {
+			rules[current] = rules[key]
+		}
+		delete(rules, key)
+	}
+	return warnings
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationAWSAccountAllowEmptyValues ...
+	IntegrationAWSAccountAllowEmptyValues = []string{}
+)
+
+type awsAccount struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		AWSAccountID string `json:"aws_account_id"`
+	} `json:"attributes"`
+}
+
+type awsAccountsResponse struct {
+	Data []awsAccount `json:"data"`
+}
+
+// IntegrationAWSAccountGenerator ...
+type IntegrationAWSAccountGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationAWSAccountGenerator) createResources(accounts []awsAccount) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, account := range accounts {
+		resources = append(resources, g.createResource(account.ID, account.Attributes.AWSAccountID))
+	}
+	return resources
+}
+
+func (g *IntegrationAWSAccountGenerator) createResource(accountConfigID, awsAccountID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		accountConfigID,
+		fmt.Sprintf("integration_aws_account_%s", awsAccountID),
+		"datadog_integration_aws_account",
+		"datadog",
+		IntegrationAWSAccountAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each AWS account of the V2 integration create 1 TerraformResource.
+// Need AWS Account Config ID as ID for terraform resource
+func (g *IntegrationAWSAccountGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("integration_aws_account") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value, value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp awsAccountsResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/integration/aws/accounts", url.Values{}, &resp); err != nil {
+		return err
+	}
+	g.Resources = g.createResources(resp.Data)
+	return nil
+}
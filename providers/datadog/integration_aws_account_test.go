@@ -0,0 +1,80 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIntegrationAWSAccounts(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/integration/aws/accounts" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "forwarding-uuid", "type": "account", "attributes": {"aws_account_id": "123456789012", "aws_partition": "aws"}},
+			{"id": "metrics-uuid", "type": "account", "attributes": {"aws_account_id": "210987654321", "aws_partition": "aws"}}
+		]}`))
+	}))
+
+	g := &IntegrationAWSAccountGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 2 {
+		t.Fatalf("expected 2 AWS accounts, got %d", len(g.Resources))
+	}
+	forwarding, metrics := &g.Resources[0], &g.Resources[1]
+	if forwarding.InstanceState.ID != "forwarding-uuid" || forwarding.ResourceName != terraformutils.TfSanitize("integration_aws_account_123456789012") {
+		t.Errorf("unexpected AWS account %s %s", forwarding.InstanceState.ID, forwarding.ResourceName)
+	}
+
+	// as refreshed by the provider, logs_config is always set
+	forwarding.Item = map[string]interface{}{
+		"aws_account_id": "123456789012",
+		"logs_config": []interface{}{map[string]interface{}{
+			"lambda_forwarder": []interface{}{map[string]interface{}{
+				"lambdas": []interface{}{"arn:aws:lambda:us-east-1:123456789012:function:datadog-forwarder"},
+				"sources": []interface{}{"s3"},
+			}},
+		}},
+	}
+	metrics.Item = map[string]interface{}{
+		"aws_account_id": "210987654321",
+		"logs_config": []interface{}{map[string]interface{}{
+			"lambda_forwarder": []interface{}{map[string]interface{}{"lambdas": []interface{}{}, "sources": []interface{}{}}},
+		}},
+	}
+	importedResource := map[string][]terraformutils.Resource{
+		"integration_aws_account": g.Resources,
+		"integration_aws_log_collection": {
+			terraformutils.NewSimpleResource("123456789012", "integration_aws_log_collection_123456789012", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues),
+			terraformutils.NewSimpleResource("210987654321", "integration_aws_log_collection_210987654321", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues),
+		},
+	}
+	reconcileAWSLogCollection(importedResource)
+	logCollections := importedResource["integration_aws_log_collection"]
+	if len(logCollections) != 1 || logCollections[0].InstanceState.ID != "210987654321" {
+		t.Errorf("expected only the log collection of the account not forwarding logs, got %v", logCollections)
+	}
+}
@@ -17,6 +17,8 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
@@ -74,5 +76,76 @@ func (g *IntegrationAWSLogCollectionGenerator) PostConvertHook() error {
 			r.Item["services"] = []string{}
 		}
 	}
-	return nil
+	return g.DatadogService.PostConvertHook()
+}
+
+// reconcileAWSLogCollection drop the V1 log collection of accounts whose logs
+// are already configured by the logs_config of an imported V2 AWS account, as
+// both would manage the same log forwarder settings
+func reconcileAWSLogCollection(importedResource map[string][]terraformutils.Resource) {
+	logCollections, exist := importedResource["integration_aws_log_collection"]
+	if !exist {
+		return
+	}
+
+	v2LogsConfigs := map[string]bool{}
+	for _, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type != "datadog_integration_aws_account" {
+				continue
+			}
+			// logs_config is a required block, it only overlaps when it forwards logs
+			forwarded := len(terraformutils.WalkAndGet("logs_config.lambda_forwarder.lambdas", r.Item)) +
+				len(terraformutils.WalkAndGet("logs_config.lambda_forwarder.sources", r.Item))
+			if accountID, ok := r.Item["aws_account_id"].(string); ok && forwarded > 0 {
+				v2LogsConfigs[accountID] = true
+			}
+		}
+	}
+	if len(v2LogsConfigs) == 0 {
+		return
+	}
+
+	reconciled := []terraformutils.Resource{}
+	for _, r := range logCollections {
+		if v2LogsConfigs[r.InstanceState.ID] {
+			log.Printf("[WARN] AWS account %s log collection is configured by both datadog_integration_aws_log_collection and datadog_integration_aws_account logs_config, keeping logs_config", r.InstanceState.ID)
+			continue
+		}
+		reconciled = append(reconciled, r)
+	}
+	importedResource["integration_aws_log_collection"] = reconciled
+}
+
+// lambdaForwarderWarnings return a warning for each imported log collection
+// enabling services of an account without an imported forwarder lambda ARN,
+// the logs of those services would be sent nowhere
+func lambdaForwarderWarnings(importedResource map[string][]terraformutils.Resource) []string {
+	logCollections, exist := importedResource["integration_aws_log_collection"]
+	if !exist {
+		return nil
+	}
+	lambdaARNs, exist := importedResource["integration_aws_lambda_arn"]
+	if !exist {
+		return nil
+	}
+
+	forwarders := map[string]bool{}
+	for _, r := range lambdaARNs {
+		// The lambda ARN id is '<account_id> <lambda_arn>'
+		forwarders[strings.SplitN(r.InstanceState.ID, " ", 2)[0]] = true
+	}
+	var warnings []string
+	for _, r := range logCollections {
+		services := terraformutils.WalkAndGet("services", r.Item)
+		if len(services) == 0 || forwarders[r.InstanceState.ID] {
+			continue
+		}
+		names := make([]string, 0, len(services))
+		for _, service := range services {
+			names = append(names, fmt.Sprint(service))
+		}
+		warnings = append(warnings, fmt.Sprintf("AWS account %s collects the logs of %s but has no forwarder lambda ARN", r.InstanceState.ID, strings.Join(names, ", ")))
+	}
+	return warnings
 }
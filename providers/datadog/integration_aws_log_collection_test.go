@@ -0,0 +1,83 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestReconcileAWSLogCollection(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	account := terraformutils.NewSimpleResource("account-uuid", "integration_aws_account_123", "datadog_integration_aws_account", "datadog", []string{})
+	account.Item = map[string]interface{}{
+		"aws_account_id": "123456789012",
+		"logs_config": []interface{}{map[string]interface{}{
+			"lambda_forwarder": []interface{}{map[string]interface{}{"sources": []interface{}{"s3"}}},
+		}},
+	}
+	importedResource := map[string][]terraformutils.Resource{
+		"integration_aws_account": {account},
+		"integration_aws_log_collection": {
+			terraformutils.NewSimpleResource("123456789012", "integration_aws_log_collection_123456789012", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues),
+			terraformutils.NewSimpleResource("210987654321", "integration_aws_log_collection_210987654321", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues),
+		},
+	}
+
+	reconcileAWSLogCollection(importedResource)
+
+	logCollections := importedResource["integration_aws_log_collection"]
+	if len(logCollections) != 1 || logCollections[0].InstanceState.ID != "210987654321" {
+		t.Errorf("expected only the log collection without logs_config overlap, got %v", logCollections)
+	}
+	if !strings.Contains(logs.String(), "AWS account 123456789012 log collection is configured by both") {
+		t.Errorf("expected overlap warning, got %q", logs.String())
+	}
+}
+
+func TestLambdaForwarderWarnings(t *testing.T) {
+	forwarded := terraformutils.NewSimpleResource("123456789012", "integration_aws_log_collection_123456789012", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues)
+	forwarded.Item = map[string]interface{}{"account_id": "123456789012", "services": []interface{}{"lambda"}}
+	unforwarded := terraformutils.NewSimpleResource("210987654321", "integration_aws_log_collection_210987654321", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues)
+	unforwarded.Item = map[string]interface{}{"account_id": "210987654321", "services": []interface{}{"s3", "elb"}}
+	disabled := terraformutils.NewSimpleResource("111111111111", "integration_aws_log_collection_111111111111", "datadog_integration_aws_log_collection", "datadog", IntegrationAWSLogCollectionAllowEmptyValues)
+	disabled.Item = map[string]interface{}{"account_id": "111111111111", "services": []string{}}
+	importedResource := map[string][]terraformutils.Resource{
+		"integration_aws_log_collection": {forwarded, unforwarded, disabled},
+		"integration_aws_lambda_arn": {
+			terraformutils.NewSimpleResource("123456789012 arn:aws:lambda:us-east-1:123456789012:function:datadog-forwarder", "integration_aws_lambda_arn_123456789012", "datadog_integration_aws_lambda_arn", "datadog", IntegrationAWSLambdaARNAllowEmptyValues),
+		},
+	}
+
+	warnings := lambdaForwarderWarnings(importedResource)
+	expected := []string{"AWS account 210987654321 collects the logs of s3, elb but has no forwarder lambda ARN"}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+
+	delete(importedResource, "integration_aws_lambda_arn")
+	if warnings := lambdaForwarderWarnings(importedResource); len(warnings) != 0 {
+		t.Errorf("unexpected warnings without imported lambda ARNs %v", warnings)
+	}
+}
@@ -17,6 +17,7 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
@@ -28,16 +29,54 @@ var (
 	IntegrationAzureAllowEmptyValues = []string{}
 )
 
+// azureAccount is an Azure integration with the metrics settings and filters
+// the API client doesn't know of
+type azureAccount struct {
+	TenantName              string `json:"tenant_name"`
+	ClientID                string `json:"client_id"`
+	HostFilters             string `json:"host_filters"`
+	AppServicePlanFilters   string `json:"app_service_plan_filters"`
+	ContainerAppFilters     string `json:"container_app_filters"`
+	UsageMetricsEnabled     *bool  `json:"usage_metrics_enabled"`
+	ResourceProviderConfigs []struct {
+		Namespace      string `json:"namespace"`
+		MetricsEnabled bool   `json:"metrics_enabled"`
+	} `json:"resource_provider_configs"`
+}
+
 // IntegrationAzureGenerator ...
 type IntegrationAzureGenerator struct {
 	DatadogService
 }
 
-func (g *IntegrationAzureGenerator) createResources(azureAccounts []datadogV1.AzureAccount) []terraformutils.Resource {
+func (g *IntegrationAzureGenerator) createResources(azureAccounts []azureAccount) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, account := range azureAccounts {
-		resourceID := fmt.Sprintf("%s:%s", account.GetTenantName(), account.GetClientId())
-		resources = append(resources, g.createResource(resourceID))
+		resourceID := fmt.Sprintf("%s:%s", account.TenantName, account.ClientID)
+		resource := g.createResource(resourceID)
+		for field, filters := range map[string]string{
+			"host_filters":             account.HostFilters,
+			"app_service_plan_filters": account.AppServicePlanFilters,
+			"container_app_filters":    account.ContainerAppFilters,
+		} {
+			if filters != "" {
+				resource.AdditionalFields[field] = filters
+			}
+		}
+		if account.UsageMetricsEnabled != nil {
+			resource.AdditionalFields["usage_metrics_enabled"] = *account.UsageMetricsEnabled
+		}
+		if len(account.ResourceProviderConfigs) > 0 {
+			configs := []interface{}{}
+			for _, config := range account.ResourceProviderConfigs {
+				configs = append(configs, map[string]interface{}{
+					"namespace":       config.Namespace,
+					"metrics_enabled": config.MetricsEnabled,
+				})
+			}
+			resource.AdditionalFields["resource_provider_configs"] = configs
+		}
+		resources = append(resources, resource)
 	}
 
 	return resources
@@ -54,14 +93,17 @@ func (g *IntegrationAzureGenerator) createResource(resourceID string) terraformu
 }
 
 // InitResources Generate TerraformResources from Datadog API,
-// from each monitor create 1 TerraformResource.
+// from each Azure integration create 1 TerraformResource, the metrics
+// settings (usage_metrics_enabled, resource_provider_configs) and the
+// host_filters, app_service_plan_filters and container_app_filters are mapped
+// from the API as the provider may not read them back.
 // Need IntegrationAzure ID formatted as '<tenant_name>:<client_id>' as ID for terraform resource
 func (g *IntegrationAzureGenerator) InitResources() error {
 	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
 	authV1 := g.Args["authV1"].(context.Context)
 
-	integrations, _, err := datadogClientV1.AzureIntegrationApi.ListAzureIntegration(authV1).Execute()
-	if err != nil {
+	var integrations []azureAccount
+	if err := getV1(datadogClientV1, authV1, "/api/v1/integration/azure", url.Values{}, &integrations); err != nil {
 		return err
 	}
 	g.Resources = g.createResources(integrations)
@@ -0,0 +1,115 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestIntegrationAzureMetricsConfig(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/integration/azure" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"tenant_name": "tenant", "client_id": "client", "usage_metrics_enabled": true,
+			"resource_provider_configs": [{"namespace": "Microsoft.Compute", "metrics_enabled": false}]}]`))
+	}))
+
+	g := &IntegrationAzureGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "tenant:client" {
+		t.Fatalf("unexpected Azure integrations %v", g.Resources)
+	}
+
+	// the metrics settings survive a state the provider read without them
+	resource := g.Resources[0]
+	resource.InstanceState.Attributes = map[string]string{
+		"id":          "tenant:client",
+		"tenant_name": "tenant",
+		"client_id":   "client",
+	}
+	parseTestState(t, &resource, cty.Object(map[string]cty.Type{
+		"tenant_name":           cty.String,
+		"client_id":             cty.String,
+		"usage_metrics_enabled": cty.Bool,
+		"resource_provider_configs": cty.List(cty.Object(map[string]cty.Type{
+			"namespace":       cty.String,
+			"metrics_enabled": cty.Bool,
+		})),
+	}))
+	if resource.Item["usage_metrics_enabled"] != true {
+		t.Errorf("usage_metrics_enabled was not mapped: %v", resource.Item)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"namespace": "Microsoft.Compute", "metrics_enabled": false},
+	}
+	if configs := resource.Item["resource_provider_configs"]; !reflect.DeepEqual(configs, expected) {
+		t.Errorf("expected resource_provider_configs %v, got %v", expected, configs)
+	}
+}
+
+func TestIntegrationAzureFilters(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"tenant_name": "tenant", "client_id": "client", "host_filters": "env:prod",
+			"app_service_plan_filters": "team:web", "container_app_filters": "team:api"}]`))
+	}))
+
+	g := &IntegrationAzureGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 {
+		t.Fatalf("unexpected Azure integrations %v", g.Resources)
+	}
+
+	resource := g.Resources[0]
+	resource.InstanceState.Attributes = map[string]string{
+		"id":          "tenant:client",
+		"tenant_name": "tenant",
+		"client_id":   "client",
+	}
+	parseTestState(t, &resource, cty.Object(map[string]cty.Type{
+		"tenant_name":              cty.String,
+		"client_id":                cty.String,
+		"host_filters":             cty.String,
+		"app_service_plan_filters": cty.String,
+		"container_app_filters":    cty.String,
+	}))
+	for field, expected := range map[string]string{
+		"host_filters":             "env:prod",
+		"app_service_plan_filters": "team:web",
+		"container_app_filters":    "team:api",
+	} {
+		if resource.Item[field] != expected {
+			t.Errorf("expected %s %q, got %v", field, expected, resource.Item[field])
+		}
+	}
+}
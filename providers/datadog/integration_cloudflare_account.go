@@ -0,0 +1,102 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationCloudflareAccountAllowEmptyValues ...
+	IntegrationCloudflareAccountAllowEmptyValues = []string{}
+)
+
+type cloudflareAccount struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+type cloudflareAccountsResponse struct {
+	Data []cloudflareAccount `json:"data"`
+}
+
+// IntegrationCloudflareAccountGenerator ...
+type IntegrationCloudflareAccountGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationCloudflareAccountGenerator) createResources(accounts []cloudflareAccount) []terraformutils.Resource {
+	names := map[string]int{}
+	for _, account := range accounts {
+		names[account.Attributes.Name]++
+	}
+	resources := []terraformutils.Resource{}
+	for _, account := range accounts {
+		// Account names aren't unique, the duplicated ones get their id
+		name := account.Attributes.Name
+		if name == "" || names[name] > 1 {
+			name += "_" + account.ID
+		}
+		resources = append(resources, g.createResource(account.ID, name))
+	}
+
+	return resources
+}
+
+func (g *IntegrationCloudflareAccountGenerator) createResource(accountID, name string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		accountID,
+		name,
+		"datadog_integration_cloudflare_account",
+		"datadog",
+		IntegrationCloudflareAccountAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each Cloudflare account create 1 TerraformResource.
+// Need Cloudflare Account ID as ID for terraform resource
+func (g *IntegrationCloudflareAccountGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("integration_cloudflare_account") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value, "integration_cloudflare_account_"+value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp cloudflareAccountsResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/integrations/cloudflare/accounts", url.Values{}, &resp); err != nil {
+		return err
+	}
+	g.Resources = g.createResources(resp.Data)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIntegrationCloudflareAccounts(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/integrations/cloudflare/accounts" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "abc", "type": "cloudflare-accounts", "attributes": {"name": "edge", "email": "ops@example.com", "resources": ["web", "dns"]}}
+		]}`))
+	}))
+
+	g := &IntegrationCloudflareAccountGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 {
+		t.Fatalf("expected 1 cloudflare account, got %d", len(g.Resources))
+	}
+	account := g.Resources[0]
+	if account.InstanceState.ID != "abc" || account.ResourceName != terraformutils.TfSanitize("edge") {
+		t.Errorf("unexpected cloudflare account %s %s", account.InstanceState.ID, account.ResourceName)
+	}
+
+	account.Item = map[string]interface{}{"name": "edge", "email": "ops@example.com", "resources": []interface{}{"web", "dns"}}
+	extractSecrets([]terraformutils.Resource{account})
+	if account.Item["api_key"] != "${var."+strings.TrimPrefix(account.ResourceName, "tfer--")+"_api_key}" {
+		t.Errorf("expected the api_key to reference its variable, got %v", account.Item["api_key"])
+	}
+}
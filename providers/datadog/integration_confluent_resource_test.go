@@ -0,0 +1,66 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIntegrationConfluent(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/integrations/confluent-cloud/accounts":
+			_, _ = w.Write([]byte(`{"data": [{"id": "abc", "type": "confluent-cloud-accounts", "attributes": {"api_key": "KEY", "tags": ["env:prod"]}}]}`))
+		case "/api/v2/integrations/confluent-cloud/accounts/abc/resources":
+			_, _ = w.Write([]byte(`{"data": [{"id": "lkc-123", "type": "confluent-cloud-resources", "attributes": {"resource_type": "kafka"}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	args := map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	}
+
+	accounts := &IntegrationConfluentAccountGenerator{}
+	accounts.SetArgs(args)
+	if err := accounts.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts.Resources) != 1 || accounts.Resources[0].InstanceState.ID != "abc" {
+		t.Fatalf("unexpected confluent accounts %v", accounts.Resources)
+	}
+
+	resources := &IntegrationConfluentResourceGenerator{}
+	resources.SetArgs(args)
+	if err := resources.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(resources.Resources) != 1 || resources.Resources[0].InstanceState.ID != "abc:lkc-123" {
+		t.Fatalf("unexpected confluent resources %v", resources.Resources)
+	}
+
+	account := accounts.Resources[0]
+	account.Item = map[string]interface{}{"api_key": "KEY"}
+	secrets := extractSecrets([]terraformutils.Resource{account})
+	name := "integration_confluent_account_abc_api_secret"
+	if account.Item["api_secret"] != "${var."+name+"}" || secrets[name] == "" {
+		t.Errorf("expected the api_secret to reference its variable, got %v", account.Item["api_secret"])
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationFastlyAccountAllowEmptyValues ...
+	IntegrationFastlyAccountAllowEmptyValues = []string{}
+)
+
+type fastlyAccountsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// listFastlyAccountIDs list the Fastly accounts of the V2 Fastly integration API
+func listFastlyAccountIDs(client *datadogV2.APIClient, auth context.Context) ([]string, error) {
+	var resp fastlyAccountsResponse
+	if err := getV2(client, auth, "/api/v2/integrations/fastly/accounts", url.Values{}, &resp); err != nil {
+		return nil, err
+	}
+	accountIDs := make([]string, 0, len(resp.Data))
+	for _, account := range resp.Data {
+		accountIDs = append(accountIDs, account.ID)
+	}
+	return accountIDs, nil
+}
+
+// IntegrationFastlyAccountGenerator ...
+type IntegrationFastlyAccountGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationFastlyAccountGenerator) createResource(accountID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		accountID,
+		fmt.Sprintf("integration_fastly_account_%s", accountID),
+		"datadog_integration_fastly_account",
+		"datadog",
+		IntegrationFastlyAccountAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each Fastly account create 1 TerraformResource.
+// Need Fastly Account ID as ID for terraform resource
+func (g *IntegrationFastlyAccountGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("integration_fastly_account") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	accountIDs, err := listFastlyAccountIDs(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	for _, accountID := range accountIDs {
+		resources = append(resources, g.createResource(accountID))
+	}
+	g.Resources = resources
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationFastlyServiceAllowEmptyValues ...
+	IntegrationFastlyServiceAllowEmptyValues = []string{"tags."}
+)
+
+type fastlyServicesResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// IntegrationFastlyServiceGenerator ...
+type IntegrationFastlyServiceGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationFastlyServiceGenerator) createResource(accountID, serviceID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		fmt.Sprintf("%s:%s", accountID, serviceID),
+		fmt.Sprintf("integration_fastly_service_%s_%s", accountID, serviceID),
+		"datadog_integration_fastly_service",
+		"datadog",
+		IntegrationFastlyServiceAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each service of each Fastly account create 1 TerraformResource.
+// Need Fastly Account ID and Service ID joined by ":" as ID for terraform resource
+func (g *IntegrationFastlyServiceGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	accountIDs, err := listFastlyAccountIDs(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	resources := []terraformutils.Resource{}
+	for _, accountID := range accountIDs {
+		var resp fastlyServicesResponse
+		err := getV2(datadogClientV2, authV2, fmt.Sprintf("/api/v2/integrations/fastly/accounts/%s/services", url.PathEscape(accountID)), url.Values{}, &resp)
+		if err != nil {
+			return err
+		}
+		for _, service := range resp.Data {
+			resources = append(resources, g.createResource(accountID, service.ID))
+		}
+	}
+	g.Resources = resources
+	return nil
+}
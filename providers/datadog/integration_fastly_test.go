@@ -0,0 +1,107 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIntegrationFastlyServiceAccount(t *testing.T) {
+	account := terraformutils.NewResource("account-1", "integration_fastly_account_1", "datadog_integration_fastly_account", "datadog", map[string]string{"id": "account-1"}, []string{}, map[string]interface{}{})
+	newService := func(id string, tags ...interface{}) terraformutils.Resource {
+		service := terraformutils.NewSimpleResource(id, "integration_fastly_service_"+id, "datadog_integration_fastly_service", "datadog", []string{"tags."})
+		service.Item = map[string]interface{}{
+			"account_id": "account-1",
+			"service_id": id,
+			"tags":       tags,
+		}
+		return service
+	}
+
+	importedResource := terraformutils.ConnectServices(map[string][]terraformutils.Resource{
+		"integration_fastly_account": {account},
+		"integration_fastly_service": {
+			newService("service-1", "env:prod", "team:edge"),
+			newService("service-2", "env:staging"),
+		},
+	}, false, DatadogProvider{}.GetResourceConnections())
+
+	expectedTags := [][]interface{}{{"env:prod", "team:edge"}, {"env:staging"}}
+	for i, service := range importedResource["integration_fastly_service"] {
+		if accountID := service.Item["account_id"]; accountID != "${data.terraform_remote_state.local.outputs.datadog_integration_fastly_account_tfer--integration_fastly_account_1_id}" {
+			t.Errorf("%s does not reference its account: %v", service.InstanceState.ID, accountID)
+		}
+		if tags := service.Item["tags"]; !reflect.DeepEqual(tags, expectedTags[i]) {
+			t.Errorf("%s: expected tags %v, got %v", service.InstanceState.ID, expectedTags[i], tags)
+		}
+	}
+}
+
+func TestIntegrationFastlyAccounts(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/integrations/fastly/accounts":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "account-1", "type": "fastly-accounts", "attributes": {"name": "edge"}},
+				{"id": "account-2", "type": "fastly-accounts", "attributes": {"name": "media"}}
+			]}`))
+		case "/api/v2/integrations/fastly/accounts/account-1/services":
+			_, _ = w.Write([]byte(`{"data": [{"id": "service-1", "type": "fastly-services", "attributes": {"tags": ["env:prod"]}}]}`))
+		case "/api/v2/integrations/fastly/accounts/account-2/services":
+			_, _ = w.Write([]byte(`{"data": [{"id": "service-2", "type": "fastly-services", "attributes": {"tags": []}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	args := map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	}
+
+	accounts := &IntegrationFastlyAccountGenerator{}
+	accounts.SetArgs(args)
+	if err := accounts.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts.Resources) != 2 {
+		t.Fatalf("expected 2 fastly accounts, got %d", len(accounts.Resources))
+	}
+
+	services := &IntegrationFastlyServiceGenerator{}
+	services.SetArgs(args)
+	if err := services.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, service := range services.Resources {
+		ids = append(ids, service.InstanceState.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"account-1:service-1", "account-2:service-2"}) {
+		t.Errorf("expected the services of both accounts, got %v", ids)
+	}
+
+	account := accounts.Resources[0]
+	account.Item = map[string]interface{}{"name": "edge"}
+	extractSecrets([]terraformutils.Resource{account})
+	if account.Item["api_key"] != "${var."+strings.TrimPrefix(account.ResourceName, "tfer--")+"_api_key}" {
+		t.Errorf("expected the api_key to reference its variable, got %v", account.Item["api_key"])
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationGCPStsAllowEmptyValues ...
+	IntegrationGCPStsAllowEmptyValues = []string{}
+)
+
+type gcpStsAccountsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// IntegrationGCPStsGenerator ...
+type IntegrationGCPStsGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationGCPStsGenerator) createResource(accountID string) terraformutils.Resource {
+	resource := terraformutils.NewSimpleResource(
+		accountID,
+		fmt.Sprintf("integration_gcp_sts_%s", accountID),
+		"datadog_integration_gcp_sts",
+		"datadog",
+		IntegrationGCPStsAllowEmptyValues,
+	)
+	// The delegate account email is computed by Datadog, it is kept in the
+	// state but never written to the configuration
+	resource.IgnoreKeys = append(resource.IgnoreKeys, "^delegate_account_email$")
+	return resource
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each STS delegated GCP service account create 1 TerraformResource.
+// The key based accounts are exported by integration_gcp.
+// Need GCP STS Account ID as ID for terraform resource
+func (g *IntegrationGCPStsGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("integration_gcp_sts") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp gcpStsAccountsResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/integration/gcp/accounts", url.Values{}, &resp); err != nil {
+		return err
+	}
+	for _, account := range resp.Data {
+		resources = append(resources, g.createResource(account.ID))
+	}
+	g.Resources = resources
+	return nil
+}
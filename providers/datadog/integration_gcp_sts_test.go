@@ -0,0 +1,66 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestIntegrationGCPSts(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/integration/gcp/accounts" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [{"id": "abc", "type": "gcp_service_account", "attributes": {
+			"client_email": "datadog@project.iam.gserviceaccount.com", "automute": true, "is_cspm_enabled": false, "host_filters": ["env:prod"]
+		}}]}`))
+	}))
+
+	g := &IntegrationGCPStsGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "abc" || g.Resources[0].InstanceInfo.Type != "datadog_integration_gcp_sts" {
+		t.Fatalf("unexpected GCP STS accounts %v", g.Resources)
+	}
+
+	// the computed delegate account email stays in the state only
+	account := g.Resources[0]
+	account.InstanceState.Attributes = map[string]string{
+		"id":                     "abc",
+		"client_email":           "datadog@project.iam.gserviceaccount.com",
+		"automute":               "true",
+		"delegate_account_email": "ddgci-abc@datadog-gci-sts.iam.gserviceaccount.com",
+	}
+	parseTestState(t, &account, cty.Object(map[string]cty.Type{
+		"client_email":           cty.String,
+		"automute":               cty.Bool,
+		"delegate_account_email": cty.String,
+	}))
+	if _, ok := account.Item["delegate_account_email"]; ok || account.Item["client_email"] != "datadog@project.iam.gserviceaccount.com" {
+		t.Errorf("expected the delegate account email out of the configuration, got %v", account.Item)
+	}
+	if account.InstanceState.Attributes["delegate_account_email"] == "" {
+		t.Errorf("expected the delegate account email to be kept in the state")
+	}
+}
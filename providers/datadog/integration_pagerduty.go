@@ -0,0 +1,81 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/url"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationPagerdutyAllowEmptyValues ...
+	IntegrationPagerdutyAllowEmptyValues = []string{}
+)
+
+// pagerdutyIntegration is the PagerDuty integration configuration returned by
+// /api/v1/integration/pagerduty, the client only covers its service objects
+type pagerdutyIntegration struct {
+	Subdomain string `json:"subdomain"`
+	Services  []struct {
+		ServiceName string `json:"service_name"`
+	} `json:"services"`
+}
+
+// getPagerdutyIntegration fetch the PagerDuty integration and its service objects
+func getPagerdutyIntegration(client *datadogV1.APIClient, auth context.Context) (pagerdutyIntegration, error) {
+	var integration pagerdutyIntegration
+	err := getV1(client, auth, "/api/v1/integration/pagerduty", url.Values{}, &integration)
+	return integration, err
+}
+
+// IntegrationPagerdutyGenerator ...
+type IntegrationPagerdutyGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationPagerdutyGenerator) createResource(subdomain string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		subdomain,
+		subdomain,
+		"datadog_integration_pagerduty",
+		"datadog",
+		IntegrationPagerdutyAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from the PagerDuty integration create 1 TerraformResource, the subdomain,
+// schedules and api_token are read back by the provider.
+// Need PagerDuty Subdomain as ID for terraform resource
+func (g *IntegrationPagerdutyGenerator) InitResources() error {
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	integration, err := getPagerdutyIntegration(datadogClientV1, authV1)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if integration.Subdomain != "" {
+		g.Resources = []terraformutils.Resource{g.createResource(integration.Subdomain)}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationPagerdutyServiceObjectAllowEmptyValues ...
+	IntegrationPagerdutyServiceObjectAllowEmptyValues = []string{}
+)
+
+// IntegrationPagerdutyServiceObjectGenerator ...
+type IntegrationPagerdutyServiceObjectGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationPagerdutyServiceObjectGenerator) createResource(serviceName string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		serviceName,
+		serviceName,
+		"datadog_integration_pagerduty_service_object",
+		"datadog",
+		IntegrationPagerdutyServiceObjectAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each PagerDuty service object create 1 TerraformResource, the
+// service_key is not returned and replaced by a variable when printed.
+// Need Service Name as ID for terraform resource
+func (g *IntegrationPagerdutyServiceObjectGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("integration_pagerduty_service_object") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	integration, err := getPagerdutyIntegration(datadogClientV1, authV1)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, service := range integration.Services {
+		resources = append(resources, g.createResource(service.ServiceName))
+	}
+	g.Resources = resources
+	return nil
+}
+
+// linkPagerdutyServiceObjects make the PagerDuty service objects depend on the
+// PagerDuty integration exported with them, they need it to exist and have no
+// attribute referencing it
+func linkPagerdutyServiceObjects(importedResource map[string][]terraformutils.Resource, isServicePath bool) {
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type != "datadog_integration_pagerduty_service_object" {
+				continue
+			}
+			var dependsOn []interface{}
+			for integrationService, integrations := range importedResource {
+				for _, integration := range integrations {
+					if integration.InstanceInfo.Type == "datadog_integration_pagerduty" {
+						dependsOn = appendAddress(dependsOn, connectedAddress(service, integrationService, integration, isServicePath))
+					}
+				}
+			}
+			if len(dependsOn) > 0 {
+				r.Item["depends_on"] = dependsOn
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestIntegrationPagerdutyGenerators(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/integration/pagerduty" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"subdomain": "acme",
+			"api_token": "*****",
+			"schedules": ["https://acme.pagerduty.com/schedules#PABC123"],
+			"services": [{"service_name": "payments-oncall", "service_key": "*****"}, {"service_name": "core"}]
+		}`))
+	}))
+	args := map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	}
+
+	integration := &IntegrationPagerdutyGenerator{}
+	integration.SetArgs(args)
+	if err := integration.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(integration.Resources) != 1 || integration.Resources[0].InstanceState.ID != "acme" {
+		t.Errorf("unexpected PagerDuty integration %v", integration.Resources)
+	}
+
+	serviceObjects := &IntegrationPagerdutyServiceObjectGenerator{}
+	serviceObjects.SetArgs(args)
+	if err := serviceObjects.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, r := range serviceObjects.Resources {
+		ids = append(ids, r.InstanceState.ID)
+	}
+	if expected := []string{"payments-oncall", "core"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected service objects %v, got %v", expected, ids)
+	}
+}
+
+func TestIntegrationPagerdutySecrets(t *testing.T) {
+	integration := terraformutils.NewResource("acme", "acme", "datadog_integration_pagerduty", "datadog", map[string]string{
+		"id":        "acme",
+		"subdomain": "acme",
+		"api_token": "*****",
+	}, IntegrationPagerdutyAllowEmptyValues, map[string]interface{}{})
+	integration.Item = map[string]interface{}{"subdomain": "acme", "api_token": "*****"}
+	serviceObject := terraformutils.NewResource("core", "core", "datadog_integration_pagerduty_service_object", "datadog", map[string]string{
+		"id":           "core",
+		"service_name": "core",
+		"service_key":  "*****",
+	}, IntegrationPagerdutyServiceObjectAllowEmptyValues, map[string]interface{}{})
+	serviceObject.Item = map[string]interface{}{"service_name": "core", "service_key": "*****"}
+
+	p := &DatadogProvider{}
+	if _, err := p.PrintHook(t.TempDir(), "hcl", []terraformutils.Resource{integration, serviceObject}); err != nil {
+		t.Fatal(err)
+	}
+
+	if token := integration.Item["api_token"]; token != "${var.acme_api_token}" {
+		t.Errorf("expected api_token to reference its variable, got %v", token)
+	}
+	if key := serviceObject.Item["service_key"]; key != "${var.core_service_key}" {
+		t.Errorf("expected service_key to reference its variable, got %v", key)
+	}
+
+	importedResource := map[string][]terraformutils.Resource{
+		"integration_pagerduty":                {integration},
+		"integration_pagerduty_service_object": {serviceObject},
+	}
+	p.ConnectHook(importedResource, true)
+	expected := []interface{}{"data.terraform_remote_state.integration_pagerduty"}
+	if dependsOn := serviceObject.Item["depends_on"]; !reflect.DeepEqual(dependsOn, expected) {
+		t.Errorf("expected the service object to depend on %v, got %v", expected, dependsOn)
+	}
+	p.ConnectHook(importedResource, false)
+	expected = []interface{}{"datadog_integration_pagerduty.tfer--acme"}
+	if dependsOn := serviceObject.Item["depends_on"]; !reflect.DeepEqual(dependsOn, expected) {
+		t.Errorf("expected the service object to depend on %v, got %v", expected, dependsOn)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IntegrationSlackChannelAllowEmptyValues ...
+	IntegrationSlackChannelAllowEmptyValues = []string{}
+)
+
+// slackIntegration is the Slack integration configuration returned by
+// /api/v1/integration/slack, listing the connected accounts
+type slackIntegration struct {
+	ServiceHooks []struct {
+		Account string `json:"account"`
+	} `json:"service_hooks"`
+}
+
+type slackChannel struct {
+	Name string `json:"name"`
+}
+
+// IntegrationSlackChannelGenerator ...
+type IntegrationSlackChannelGenerator struct {
+	DatadogService
+}
+
+func (g *IntegrationSlackChannelGenerator) createResource(accountName, channelName string) terraformutils.Resource {
+	resourceID := fmt.Sprintf("%s:%s", accountName, channelName)
+	return terraformutils.NewResource(
+		resourceID,
+		fmt.Sprintf("integration_slack_channel_%s", resourceID),
+		"datadog_integration_slack_channel",
+		"datadog",
+		map[string]string{
+			"account_name": accountName,
+			"channel_name": channelName,
+		},
+		IntegrationSlackChannelAllowEmptyValues,
+		map[string]interface{}{},
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each channel of each Slack account create 1 TerraformResource, the
+// display block is read back by the provider.
+// Need IntegrationSlackChannel ID formatted as '<account_name>:<channel_name>' as ID for terraform resource
+func (g *IntegrationSlackChannelGenerator) InitResources() error {
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	var integration slackIntegration
+	err := getV1(datadogClientV1, authV1, "/api/v1/integration/slack", url.Values{}, &integration)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	resources := []terraformutils.Resource{}
+	for _, hook := range integration.ServiceHooks {
+		var channels []slackChannel
+		path := fmt.Sprintf("/api/v1/integration/slack/configuration/accounts/%s/channels", url.PathEscape(hook.Account))
+		if err := getV1(datadogClientV1, authV1, path, url.Values{}, &channels); err != nil {
+			return err
+		}
+		for _, channel := range channels {
+			resources = append(resources, g.createResource(hook.Account, channel.Name))
+		}
+	}
+	g.Resources = resources
+	return nil
+}
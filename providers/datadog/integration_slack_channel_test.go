@@ -0,0 +1,57 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIntegrationSlackChannelGenerator(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/integration/slack":
+			_, _ = w.Write([]byte(`{"service_hooks": [{"account": "main", "url": "*****"}, {"account": "ops", "url": "*****"}]}`))
+		case "/api/v1/integration/slack/configuration/accounts/main/channels":
+			_, _ = w.Write([]byte(`[{"name": "#alerts", "display": {"message": true, "notified": true, "snapshot": false, "tags": true}}]`))
+		case "/api/v1/integration/slack/configuration/accounts/ops/channels":
+			_, _ = w.Write([]byte(`[{"name": "#oncall"}, {"name": "#deploys"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	g := &IntegrationSlackChannelGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, r := range g.Resources {
+		ids = append(ids, r.InstanceState.ID)
+	}
+	if expected := []string{"main:#alerts", "ops:#oncall", "ops:#deploys"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected channels %v, got %v", expected, ids)
+	}
+	if attributes := g.Resources[0].InstanceState.Attributes; attributes["account_name"] != "main" || attributes["channel_name"] != "#alerts" {
+		t.Errorf("unexpected attributes %v", attributes)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// IPAllowlistAllowEmptyValues ...
+	IPAllowlistAllowEmptyValues = []string{"note"}
+)
+
+type ipAllowlistEntry struct {
+	Data struct {
+		Attributes struct {
+			CIDRBlock string `json:"cidr_block"`
+			Note      string `json:"note"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+type ipAllowlistResponse struct {
+	Data struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Enabled bool               `json:"enabled"`
+			Entries []ipAllowlistEntry `json:"entries"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// IPAllowlistGenerator ...
+type IPAllowlistGenerator struct {
+	DatadogService
+	enabled bool
+	entries []ipAllowlistEntry
+}
+
+// InitResources Generate TerraformResources from Datadog API, the allowlist
+// is a singleton so its name is constant to keep re-exports stable
+func (g *IPAllowlistGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp ipAllowlistResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/ip_allowlist", url.Values{}, &resp); err != nil {
+		return err
+	}
+	g.enabled = resp.Data.Attributes.Enabled
+	g.entries = resp.Data.Attributes.Entries
+	g.Resources = []terraformutils.Resource{terraformutils.NewSimpleResource(
+		resp.Data.ID,
+		"ip_allowlist",
+		"datadog_ip_allowlist",
+		"datadog",
+		IPAllowlistAllowEmptyValues,
+	)}
+	return nil
+}
+
+// PostConvertHook write the enabled flag and the entries as returned by the
+// API, a disabled allowlist keeps its entries to enable it again later
+func (g *IPAllowlistGenerator) PostConvertHook() error {
+	for i := range g.Resources {
+		entries := []interface{}{}
+		for _, entry := range g.entries {
+			entries = append(entries, map[string]interface{}{
+				"cidr_block": entry.Data.Attributes.CIDRBlock,
+				"note":       entry.Data.Attributes.Note,
+			})
+		}
+		g.Resources[i].Item["enabled"] = g.enabled
+		g.Resources[i].Item["entry"] = entries
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestIPAllowlistDisabledKeepsEntries(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/ip_allowlist" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"id": "allowlist-1", "type": "ip_allowlist", "attributes": {
+			"enabled": false,
+			"entries": [
+				{"data": {"id": "e1", "type": "ip_allowlist_entry", "attributes": {"cidr_block": "10.0.0.0/8", "note": "office"}}},
+				{"data": {"id": "e2", "type": "ip_allowlist_entry", "attributes": {"cidr_block": "192.168.1.1/32"}}}
+			]
+		}}}`))
+	}))
+
+	g := &IPAllowlistGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].ResourceName != "tfer--ip_allowlist" {
+		t.Fatalf("expected a single allowlist, got %v", g.Resources)
+	}
+	// the provider drops the entries of a disabled allowlist
+	g.Resources[0].Item = map[string]interface{}{}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	item := g.Resources[0].Item
+	if item["enabled"] != false {
+		t.Errorf("expected the allowlist to stay disabled, got %v", item["enabled"])
+	}
+	expected := []interface{}{
+		map[string]interface{}{"cidr_block": "10.0.0.0/8", "note": "office"},
+		map[string]interface{}{"cidr_block": "192.168.1.1/32", "note": ""},
+	}
+	if !reflect.DeepEqual(item["entry"], expected) {
+		t.Errorf("expected the entries to be kept, got %v", item["entry"])
+	}
+}
@@ -15,6 +15,8 @@
 package datadog
 
 import (
+	"strconv"
+
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 )
 
@@ -28,11 +30,12 @@ type LogsArchiveOrderGenerator struct {
 	DatadogService
 }
 
-// InitResources Generate TerraformResources
+// InitResources Generate TerraformResources, the order is a singleton so
+// its name is constant to keep re-exports stable
 func (g *LogsArchiveOrderGenerator) InitResources() error {
 	g.Resources = append(g.Resources, terraformutils.NewResource(
 		"archiveOrderID",
-		"archiveOrderID",
+		"logs_archive_order",
 		"datadog_logs_archive_order",
 		"datadog",
 		map[string]string{},
@@ -41,3 +44,31 @@ func (g *LogsArchiveOrderGenerator) InitResources() error {
 	))
 	return nil
 }
+
+// inferLogsArchiveOrder add a logs_archive_order from the imported archives
+// when the order itself isn't imported, the archives are listed by the API in
+// their priority order
+func inferLogsArchiveOrder(importedResource map[string][]terraformutils.Resource) {
+	archives := importedResource["logs_archive"]
+	if len(archives) == 0 || len(importedResource["logs_archive_order"]) > 0 {
+		return
+	}
+
+	attributes := map[string]string{"archive_ids.#": strconv.Itoa(len(archives))}
+	archiveIDs := []interface{}{}
+	for i, archive := range archives {
+		attributes["archive_ids."+strconv.Itoa(i)] = archive.InstanceState.ID
+		archiveIDs = append(archiveIDs, archive.InstanceState.ID)
+	}
+	order := terraformutils.NewResource(
+		"archiveOrderID",
+		"logs_archive_order",
+		"datadog_logs_archive_order",
+		"datadog",
+		attributes,
+		LogsArchiveOrderAllowEmptyValues,
+		map[string]interface{}{},
+	)
+	order.Item = map[string]interface{}{"archive_ids": archiveIDs}
+	importedResource["logs_archive_order"] = []terraformutils.Resource{order}
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestLogsOrderStableNames(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/logs/config/indexes":
+			_, _ = w.Write([]byte(`{"indexes": [{"name": "main"}]}`))
+		case "/api/v1/logs/config/pipelines":
+			_, _ = w.Write([]byte(`[{"id": "pipeline-1"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	for _, newGenerator := range []func() terraformutils.ServiceGenerator{
+		func() terraformutils.ServiceGenerator { return &LogsArchiveOrderGenerator{} },
+		func() terraformutils.ServiceGenerator { return &LogsIndexOrderGenerator{} },
+		func() terraformutils.ServiceGenerator { return &LogsPipelineOrderGenerator{} },
+	} {
+		var names []string
+		for run := 0; run < 2; run++ {
+			g := newGenerator()
+			g.SetArgs(map[string]interface{}{
+				"datadogClientV1": client,
+				"authV1":          auth,
+			})
+			if err := g.InitResources(); err != nil {
+				t.Fatal(err)
+			}
+			resources := g.GetResources()
+			if len(resources) != 1 {
+				t.Fatalf("expected a single order resource, got %v", resources)
+			}
+			names = append(names, resources[0].ResourceName)
+		}
+		if names[0] != names[1] {
+			t.Errorf("order resource renamed across runs: %s and %s", names[0], names[1])
+		}
+	}
+}
+
+func TestInferLogsArchiveOrder(t *testing.T) {
+	var archives []terraformutils.Resource
+	for _, id := range []string{"b-archive", "a-archive", "c-archive"} {
+		archives = append(archives, terraformutils.NewSimpleResource(id, "logs_archive_"+id, "datadog_logs_archive", "datadog", LogsArchiveAllowEmptyValues))
+	}
+	importedResource := map[string][]terraformutils.Resource{"logs_archive": archives}
+
+	inferLogsArchiveOrder(importedResource)
+	orders := importedResource["logs_archive_order"]
+	if len(orders) != 1 {
+		t.Fatalf("expected an inferred archive order, got %v", orders)
+	}
+	if !reflect.DeepEqual(orders[0].Item["archive_ids"], []interface{}{"b-archive", "a-archive", "c-archive"}) {
+		t.Errorf("expected the archives in their API order, got %v", orders[0].Item["archive_ids"])
+	}
+	if orders[0].InstanceState.Attributes["archive_ids.1"] != "a-archive" {
+		t.Errorf("unexpected archive order state %v", orders[0].InstanceState.Attributes)
+	}
+
+	// an imported order is kept as is
+	imported := importedResource["logs_archive_order"][0]
+	imported.Item = map[string]interface{}{"archive_ids": []interface{}{"c-archive"}}
+	importedResource["logs_archive_order"] = []terraformutils.Resource{imported}
+	inferLogsArchiveOrder(importedResource)
+	if !reflect.DeepEqual(importedResource["logs_archive_order"][0].Item["archive_ids"], []interface{}{"c-archive"}) {
+		t.Errorf("the imported archive order was replaced")
+	}
+}
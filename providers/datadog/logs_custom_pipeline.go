@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
@@ -30,24 +31,49 @@ var (
 	LogsCustomPipelineAllowEmptyValues = []string{"support_rules", "filter"}
 )
 
+// logsPipeline is a logs pipeline with the description and tags the API
+// client doesn't know of, its processors are read back by the provider
+type logsPipeline struct {
+	ID          string   `json:"id"`
+	IsReadOnly  bool     `json:"is_read_only"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
 // LogsCustomPipelineGenerator ...
 type LogsCustomPipelineGenerator struct {
 	DatadogService
 }
 
-func (g *LogsCustomPipelineGenerator) createResources(logsCustomPipelines []datadogV1.LogsPipeline) []terraformutils.Resource {
+func (g *LogsCustomPipelineGenerator) createResources(logsCustomPipelines []logsPipeline) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, logsCustomPipeline := range logsCustomPipelines {
 		// Import logs custom pipelines only
-		if !logsCustomPipeline.GetIsReadOnly() {
-			resourceName := logsCustomPipeline.GetId()
-			resources = append(resources, g.createResource(resourceName))
+		if !logsCustomPipeline.IsReadOnly {
+			resources = append(resources, g.createPipelineResource(logsCustomPipeline))
 		}
 	}
 
 	return resources
 }
 
+// createPipelineResource map the description and tags of the pipeline, only
+// when set as the provider has no empty default for them
+func (g *LogsCustomPipelineGenerator) createPipelineResource(logsCustomPipeline logsPipeline) terraformutils.Resource {
+	resource := g.createResource(logsCustomPipeline.ID)
+	if logsCustomPipeline.Description != "" {
+		resource.AdditionalFields["description"] = logsCustomPipeline.Description
+	}
+	if len(logsCustomPipeline.Tags) > 0 {
+		tags := make([]interface{}, 0, len(logsCustomPipeline.Tags))
+		for _, tag := range logsCustomPipeline.Tags {
+			tags = append(tags, tag)
+		}
+		resource.AdditionalFields["tags"] = tags
+	}
+	return resource
+}
+
 func (g *LogsCustomPipelineGenerator) createResource(logsCustomPipelineID string) terraformutils.Resource {
 	return terraformutils.NewSimpleResource(
 		logsCustomPipelineID,
@@ -69,12 +95,13 @@ func (g *LogsCustomPipelineGenerator) InitResources() error {
 	for _, filter := range g.Filter {
 		if filter.FieldPath == "id" && filter.IsApplicable("logs_custom_pipeline") {
 			for _, value := range filter.AcceptableValues {
-				logsCustomPipeline, _, err := datadogClientV1.LogsPipelinesApi.GetLogsPipeline(authV1, value).Execute()
+				var logsCustomPipeline logsPipeline
+				err := getV1(datadogClientV1, authV1, "/api/v1/logs/config/pipelines/"+url.PathEscape(value), url.Values{}, &logsCustomPipeline)
 				if err != nil {
 					return err
 				}
 
-				resources = append(resources, g.createResource(logsCustomPipeline.GetId()))
+				resources = append(resources, g.createPipelineResource(logsCustomPipeline))
 			}
 		}
 	}
@@ -84,8 +111,8 @@ func (g *LogsCustomPipelineGenerator) InitResources() error {
 		return nil
 	}
 
-	logsCustomPipelines, _, err := datadogClientV1.LogsPipelinesApi.ListLogsPipelines(authV1).Execute()
-	if err != nil {
+	var logsCustomPipelines []logsPipeline
+	if err := getV1(datadogClientV1, authV1, "/api/v1/logs/config/pipelines", url.Values{}, &logsCustomPipelines); err != nil {
 		return err
 	}
 	g.Resources = g.createResources(logsCustomPipelines)
@@ -110,5 +137,5 @@ func (g *LogsCustomPipelineGenerator) PostConvertHook() error {
 			}
 		}
 	}
-	return nil
+	return g.DatadogService.PostConvertHook()
 }
@@ -0,0 +1,153 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// parseTestPipeline parse the state of a custom pipeline holding a single processor
+func parseTestPipeline(t *testing.T, attributes map[string]string, processorType cty.Type, processorName string) map[string]interface{} {
+	g := &LogsCustomPipelineGenerator{}
+	resource := g.createResource("abc")
+	resource.InstanceState.Attributes = attributes
+	impliedType := cty.Object(map[string]cty.Type{
+		"name": cty.String,
+		"processor": cty.List(cty.Object(map[string]cty.Type{
+			processorName: cty.List(processorType),
+		})),
+	})
+	parseTestState(t, &resource, impliedType)
+	g.Resources = append(g.Resources, resource)
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+	processor := g.Resources[0].Item["processor"].([]interface{})[0].(map[string]interface{})
+	return processor[processorName].([]interface{})[0].(map[string]interface{})
+}
+
+func TestLogsCustomPipelineArithmeticProcessor(t *testing.T) {
+	processor := parseTestPipeline(t, map[string]string{
+		"id":                                 "abc",
+		"name":                               "payments",
+		"processor.#":                        "1",
+		"processor.0.arithmetic_processor.#": "1",
+		"processor.0.arithmetic_processor.0.name":               "duration in seconds",
+		"processor.0.arithmetic_processor.0.expression":         "(@http.end - @http.start) / 1000",
+		"processor.0.arithmetic_processor.0.target":             "duration_s",
+		"processor.0.arithmetic_processor.0.is_replace_missing": "false",
+		"processor.0.arithmetic_processor.0.is_enabled":         "true",
+	}, cty.Object(map[string]cty.Type{
+		"name":               cty.String,
+		"expression":         cty.String,
+		"target":             cty.String,
+		"is_replace_missing": cty.Bool,
+		"is_enabled":         cty.Bool,
+	}), "arithmetic_processor")
+
+	expected := map[string]interface{}{
+		"name":               "duration in seconds",
+		"expression":         "(@http.end - @http.start) / 1000",
+		"target":             "duration_s",
+		"is_replace_missing": "false",
+		"is_enabled":         "true",
+	}
+	if !reflect.DeepEqual(processor, expected) {
+		t.Errorf("expected arithmetic processor %v, got %v", expected, processor)
+	}
+}
+
+func TestLogsCustomPipelineGeoIPParser(t *testing.T) {
+	processor := parseTestPipeline(t, map[string]string{
+		"id":                                    "abc",
+		"name":                                  "web",
+		"processor.#":                           "1",
+		"processor.0.geo_ip_parser.#":           "1",
+		"processor.0.geo_ip_parser.0.name":      "client geo",
+		"processor.0.geo_ip_parser.0.sources.#": "2",
+		"processor.0.geo_ip_parser.0.sources.0": "network.client.ip",
+		"processor.0.geo_ip_parser.0.sources.1": "http.x_forwarded_for",
+		"processor.0.geo_ip_parser.0.target":    "network.client.geoip",
+	}, cty.Object(map[string]cty.Type{
+		"name":    cty.String,
+		"sources": cty.List(cty.String),
+		"target":  cty.String,
+	}), "geo_ip_parser")
+
+	expected := map[string]interface{}{
+		"name":    "client geo",
+		"sources": []interface{}{"network.client.ip", "http.x_forwarded_for"},
+		"target":  "network.client.geoip",
+	}
+	if !reflect.DeepEqual(processor, expected) {
+		t.Errorf("expected geo-ip parser %v, got %v", expected, processor)
+	}
+}
+
+func TestLogsCustomPipelineDescriptionAndTags(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/logs/config/pipelines" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "abc", "name": "payments", "is_read_only": false, "description": "payments logs", "tags": ["team:payments", "env:prod"]},
+			{"id": "def", "name": "web", "is_read_only": false},
+			{"id": "nginx", "name": "Nginx", "is_read_only": true}
+		]`))
+	}))
+
+	g := &LogsCustomPipelineGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 2 {
+		t.Fatalf("expected the 2 custom pipelines, got %v", g.Resources)
+	}
+
+	impliedType := cty.Object(map[string]cty.Type{
+		"name":        cty.String,
+		"description": cty.String,
+		"tags":        cty.List(cty.String),
+	})
+	payments := g.Resources[0]
+	payments.InstanceState.Attributes = map[string]string{"id": "abc", "name": "payments"}
+	parseTestState(t, &payments, impliedType)
+	if payments.Item["description"] != "payments logs" {
+		t.Errorf("expected the description to survive, got %v", payments.Item)
+	}
+	if tags := payments.Item["tags"]; !reflect.DeepEqual(tags, []interface{}{"team:payments", "env:prod"}) {
+		t.Errorf("expected the tags to survive in order, got %v", tags)
+	}
+
+	// a pipeline without description nor tags gets no empty value
+	web := g.Resources[1]
+	web.InstanceState.Attributes = map[string]string{"id": "def", "name": "web", "description": ""}
+	parseTestState(t, &web, impliedType)
+	if _, ok := web.Item["description"]; ok {
+		t.Errorf("unexpected empty description %v", web.Item)
+	}
+	if _, ok := web.Item["tags"]; ok {
+		t.Errorf("unexpected empty tags %v", web.Item)
+	}
+}
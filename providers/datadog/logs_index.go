@@ -17,6 +17,7 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
@@ -31,19 +32,29 @@ var (
 // LogsIndexGenerator ...
 type LogsIndexGenerator struct {
 	DatadogService
+	sampleRates map[string]map[string]float64
 }
 
 func (g *LogsIndexGenerator) createResources(logsIndexes []datadogV1.LogsIndex) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, logsIndex := range logsIndexes {
-		resourceName := logsIndex.GetName()
-		resources = append(resources, g.createResource(resourceName))
+		resources = append(resources, g.createResource(logsIndex))
 	}
 
 	return resources
 }
 
-func (g *LogsIndexGenerator) createResource(logsIndexName string) terraformutils.Resource {
+func (g *LogsIndexGenerator) createResource(logsIndex datadogV1.LogsIndex) terraformutils.Resource {
+	logsIndexName := logsIndex.GetName()
+	if g.sampleRates == nil {
+		g.sampleRates = map[string]map[string]float64{}
+	}
+	g.sampleRates[logsIndexName] = map[string]float64{}
+	for _, exclusionFilter := range logsIndex.GetExclusionFilters() {
+		filter := exclusionFilter.GetFilter()
+		g.sampleRates[logsIndexName][exclusionFilter.GetName()] = filter.GetSampleRate()
+	}
+
 	return terraformutils.NewSimpleResource(
 		logsIndexName,
 		fmt.Sprintf("logs_index_%s", logsIndexName),
@@ -69,7 +80,7 @@ func (g *LogsIndexGenerator) InitResources() error {
 					return err
 				}
 
-				resources = append(resources, g.createResource(logsIndex.GetName()))
+				resources = append(resources, g.createResource(logsIndex))
 			}
 		}
 	}
@@ -87,3 +98,30 @@ func (g *LogsIndexGenerator) InitResources() error {
 	g.Resources = g.createResources(logsIndex)
 	return nil
 }
+
+// PostConvertHook write the exclusion filters sample_rate as returned by the
+// API: the provider round-trips it through a float32, turning 0.9 into
+// 0.8999999761581421 and causing a diff on the next plan.
+func (g *LogsIndexGenerator) PostConvertHook() error {
+	for _, r := range g.Resources {
+		sampleRates := g.sampleRates[r.InstanceState.ID]
+		exclusionFilters, _ := r.Item["exclusion_filter"].([]interface{})
+		for _, e := range exclusionFilters {
+			exclusionFilter, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sampleRate, ok := sampleRates[fmt.Sprint(exclusionFilter["name"])]
+			if !ok {
+				continue
+			}
+			filters, _ := exclusionFilter["filter"].([]interface{})
+			for _, f := range filters {
+				if filter, ok := f.(map[string]interface{}); ok {
+					filter["sample_rate"] = strconv.FormatFloat(sampleRate, 'f', -1, 64)
+				}
+			}
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -15,8 +15,11 @@
 package datadog
 
 import (
-	"fmt"
-	"time"
+	"context"
+	"net/url"
+	"strconv"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 )
@@ -26,15 +29,49 @@ var (
 	LogsIndexOrderAllowEmptyValues = []string{}
 )
 
+// listLogsIndexNames list the names of all the logs indexes in their
+// processing order
+func listLogsIndexNames(client *datadogV1.APIClient, auth context.Context) ([]string, error) {
+	return listLogsConfigPages(func(pageSize, pageNumber int) ([]string, error) {
+		var resp struct {
+			Indexes []struct {
+				Name string `json:"name"`
+			} `json:"indexes"`
+		}
+		err := getV1(client, auth, "/api/v1/logs/config/indexes", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(resp.Indexes))
+		for _, index := range resp.Indexes {
+			names = append(names, index.Name)
+		}
+		return names, nil
+	})
+}
+
 // LogsIndexOrderGenerator ...
 type LogsIndexOrderGenerator struct {
 	DatadogService
+	indexNames []string
 }
 
-// InitResources Generate TerraformResources
+// InitResources Generate TerraformResources, the order is a singleton so
+// its name is constant to keep re-exports stable
 func (g *LogsIndexOrderGenerator) InitResources() error {
-	currentDate := time.Now().Format("20060102150405")
-	resourceName := fmt.Sprintf("logs_index_order_%s", currentDate)
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	indexNames, err := listLogsIndexNames(datadogClientV1, authV1)
+	if err != nil {
+		return err
+	}
+	g.indexNames = indexNames
+
+	resourceName := "logs_index_order"
 	g.Resources = append(g.Resources, terraformutils.NewResource(
 		resourceName,
 		resourceName,
@@ -48,3 +85,16 @@ func (g *LogsIndexOrderGenerator) InitResources() error {
 	))
 	return nil
 }
+
+// PostConvertHook write the indexes of every page in their processing
+// order, so the order doesn't drop the indexes of the later pages
+func (g *LogsIndexOrderGenerator) PostConvertHook() error {
+	for i := range g.Resources {
+		indexes := []interface{}{}
+		for _, indexName := range g.indexNames {
+			indexes = append(indexes, indexName)
+		}
+		g.Resources[i].Item["indexes"] = indexes
+	}
+	return g.DatadogService.PostConvertHook()
+}
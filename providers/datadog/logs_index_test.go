@@ -0,0 +1,62 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"testing"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestLogsIndexExclusionFilterSampleRate(t *testing.T) {
+	exclusionFilter := datadogV1.NewLogsExclusion("drop-debug")
+	exclusionFilter.SetFilter(*datadogV1.NewLogsExclusionFilter(0.05))
+	logsIndex := datadogV1.NewLogsIndex(datadogV1.LogsFilter{}, "main")
+	logsIndex.SetExclusionFilters([]datadogV1.LogsExclusion{*exclusionFilter})
+
+	g := &LogsIndexGenerator{}
+	g.Resources = g.createResources([]datadogV1.LogsIndex{*logsIndex})
+	// the provider stores the sample rate through a float32
+	g.Resources[0].InstanceState.Attributes = map[string]string{
+		"id":                                "main",
+		"name":                              "main",
+		"exclusion_filter.#":                "1",
+		"exclusion_filter.0.name":           "drop-debug",
+		"exclusion_filter.0.filter.#":       "1",
+		"exclusion_filter.0.filter.0.query": "status:debug",
+		"exclusion_filter.0.filter.0.sample_rate": "0.05000000074505806",
+	}
+	impliedType := cty.Object(map[string]cty.Type{
+		"name": cty.String,
+		"exclusion_filter": cty.List(cty.Object(map[string]cty.Type{
+			"name": cty.String,
+			"filter": cty.List(cty.Object(map[string]cty.Type{
+				"query":       cty.String,
+				"sample_rate": cty.Number,
+			})),
+		})),
+	})
+	parseTestState(t, &g.Resources[0], impliedType)
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	exclusion := g.Resources[0].Item["exclusion_filter"].([]interface{})[0].(map[string]interface{})
+	filter := exclusion["filter"].([]interface{})[0].(map[string]interface{})
+	if sampleRate := filter["sample_rate"]; sampleRate != "0.05" {
+		t.Errorf("expected sample_rate 0.05, got %v", sampleRate)
+	}
+}
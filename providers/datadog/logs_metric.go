@@ -0,0 +1,87 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// LogsMetricAllowEmptyValues ...
+	LogsMetricAllowEmptyValues = []string{"filter.", "group_by."}
+)
+
+// LogsMetricGenerator ...
+type LogsMetricGenerator struct {
+	DatadogService
+}
+
+func (g *LogsMetricGenerator) createResources(logsMetrics []datadogV2.LogsMetricResponseData) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, logsMetric := range logsMetrics {
+		resources = append(resources, g.createResource(logsMetric.GetId()))
+	}
+
+	return resources
+}
+
+func (g *LogsMetricGenerator) createResource(logsMetricID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		logsMetricID,
+		logsMetricID,
+		"datadog_logs_metric",
+		"datadog",
+		LogsMetricAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each logs metric create 1 TerraformResource, the compute block is
+// rebuilt from the state once all services are imported.
+// Need LogsMetric ID as ID for terraform resource
+func (g *LogsMetricGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("logs_metric") {
+			for _, value := range filter.AcceptableValues {
+				resp, _, err := datadogClientV2.LogsMetricsApi.GetLogsMetric(authV2, value).Execute()
+				if err != nil {
+					return err
+				}
+				logsMetric := resp.GetData()
+				resources = append(resources, g.createResource(logsMetric.GetId()))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	resp, _, err := datadogClientV2.LogsMetricsApi.ListLogsMetrics(authV2).Execute()
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(resp.GetData())
+	return nil
+}
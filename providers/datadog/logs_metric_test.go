@@ -0,0 +1,55 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestLogsMetricGenerator(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/logs/config/metrics" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "payments.errors", "type": "logs_metrics", "attributes": {"compute": {"aggregation_type": "count"}, "filter": {"query": "service:payments status:error"}}},
+			{"id": "web.duration", "type": "logs_metrics", "attributes": {"compute": {"aggregation_type": "distribution", "path": "@duration"}, "group_by": [{"path": "@http.status_code", "tag_name": "status_code"}]}}
+		]}`))
+	}))
+
+	g := &LogsMetricGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Resources) != 2 {
+		t.Fatalf("expected 2 logs metrics, got %v", g.Resources)
+	}
+	for i, expected := range []string{"payments.errors", "web.duration"} {
+		r := g.Resources[i]
+		if r.InstanceState.ID != expected || r.InstanceInfo.Type != "datadog_logs_metric" {
+			t.Errorf("unexpected logs metric %s %s", r.InstanceInfo.Type, r.InstanceState.ID)
+		}
+	}
+	if name := g.Resources[0].ResourceName; name != "tfer--payments-002E-errors" {
+		t.Errorf("expected the sanitized metric id as resource name, got %s", name)
+	}
+}
@@ -15,8 +15,11 @@
 package datadog
 
 import (
-	"fmt"
-	"time"
+	"context"
+	"net/url"
+	"strconv"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 )
@@ -26,15 +29,74 @@ var (
 	LogsPipelineOrderAllowEmptyValues = []string{}
 )
 
+// listLogsConfigPages page through a logs configuration list returned in
+// processing order, fetching the page number with the given size, until a
+// short page. A page bringing no new item also ends the listing, the API may
+// ignore the paging parameters and return the whole list each time.
+func listLogsConfigPages(fetch func(pageSize, pageNumber int) ([]string, error)) ([]string, error) {
+	var items []string
+	seen := map[string]bool{}
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		page, err := fetch(pageSize, pageNumber)
+		if err != nil {
+			return nil, err
+		}
+		added := false
+		for _, item := range page {
+			if !seen[item] {
+				seen[item] = true
+				added = true
+				items = append(items, item)
+			}
+		}
+		if len(page) < pageSize || !added {
+			return items, nil
+		}
+	}
+}
+
+// listLogsPipelineIDs list the ids of all the logs pipelines in their
+// processing order
+func listLogsPipelineIDs(client *datadogV1.APIClient, auth context.Context) ([]string, error) {
+	return listLogsConfigPages(func(pageSize, pageNumber int) ([]string, error) {
+		var pipelines []struct {
+			ID string `json:"id"`
+		}
+		err := getV1(client, auth, "/api/v1/logs/config/pipelines", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &pipelines)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(pipelines))
+		for _, pipeline := range pipelines {
+			ids = append(ids, pipeline.ID)
+		}
+		return ids, nil
+	})
+}
+
 // LogsPipelineOrderGenerator ...
 type LogsPipelineOrderGenerator struct {
 	DatadogService
+	pipelineIDs []string
 }
 
-// InitResources Generate TerraformResources
+// InitResources Generate TerraformResources, the order is a singleton so
+// its name is constant to keep re-exports stable
 func (g *LogsPipelineOrderGenerator) InitResources() error {
-	currentDate := time.Now().Format("20060102150405")
-	resourceName := fmt.Sprintf("logs_pipeline_order_%s", currentDate)
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	pipelineIDs, err := listLogsPipelineIDs(datadogClientV1, authV1)
+	if err != nil {
+		return err
+	}
+	g.pipelineIDs = pipelineIDs
+
+	resourceName := "logs_pipeline_order"
 	g.Resources = append(g.Resources, terraformutils.NewResource(
 		resourceName,
 		resourceName,
@@ -48,3 +110,16 @@ func (g *LogsPipelineOrderGenerator) InitResources() error {
 	))
 	return nil
 }
+
+// PostConvertHook write the pipelines of every page in their processing
+// order, so the order doesn't drop the pipelines of the later pages
+func (g *LogsPipelineOrderGenerator) PostConvertHook() error {
+	for i := range g.Resources {
+		pipelines := []interface{}{}
+		for _, pipelineID := range g.pipelineIDs {
+			pipelines = append(pipelines, pipelineID)
+		}
+		g.Resources[i].Item["pipelines"] = pipelines
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLogsPipelineOrderPagination(t *testing.T) {
+	pipelines := func(from, to int) string {
+		var page []string
+		for i := from; i < to; i++ {
+			page = append(page, fmt.Sprintf(`{"id": "p%d", "name": "pipeline %d", "is_enabled": true}`, i, i))
+		}
+		return "[" + strings.Join(page, ",") + "]"
+	}
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/logs/config/pipelines" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page[number]") != "0" {
+			_, _ = w.Write([]byte(pipelines(100, 102)))
+			return
+		}
+		_, _ = w.Write([]byte(pipelines(0, 100)))
+	}))
+
+	g := &LogsPipelineOrderGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	g.Resources[0].Item = map[string]interface{}{"name": "logs_pipeline_order", "pipelines": []interface{}{"p0"}}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{}
+	for i := 0; i < 102; i++ {
+		expected = append(expected, fmt.Sprintf("p%d", i))
+	}
+	if !reflect.DeepEqual(g.Resources[0].Item["pipelines"], expected) {
+		t.Errorf("expected the pipelines of both pages in order, got %v", g.Resources[0].Item["pipelines"])
+	}
+}
+
+func TestListLogsConfigPagesIgnoredPaging(t *testing.T) {
+	requests := 0
+	items, err := listLogsConfigPages(func(pageSize, pageNumber int) ([]string, error) {
+		requests++
+		page := make([]string, pageSize+10)
+		for i := range page {
+			page[i] = fmt.Sprintf("i%d", i)
+		}
+		return page, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 110 || requests != 2 {
+		t.Errorf("expected the whole list once in 2 requests, got %d items in %d requests", len(items), requests)
+	}
+}
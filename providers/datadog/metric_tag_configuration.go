@@ -0,0 +1,179 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// MetricTagConfigurationAllowEmptyValues ...
+	MetricTagConfigurationAllowEmptyValues = []string{}
+)
+
+// metricTagConfiguration is the tag configuration of a custom metric
+// returned by /api/v2/metrics/{metric_name}/tags
+type metricTagConfiguration struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		MetricType   string   `json:"metric_type"`
+		Tags         []string `json:"tags"`
+		Aggregations []struct {
+			Space string `json:"space"`
+			Time  string `json:"time"`
+		} `json:"aggregations"`
+		IncludePercentiles *bool `json:"include_percentiles"`
+		ExcludeTagsMode    *bool `json:"exclude_tags_mode"`
+	} `json:"attributes"`
+}
+
+type metricsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	Meta struct {
+		Pagination struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+type metricTagConfigurationResponse struct {
+	Data metricTagConfiguration `json:"data"`
+}
+
+// listMetrics page through the V2 metrics API, listing the metrics actively
+// reporting
+func listMetrics(client *datadogV2.APIClient, auth context.Context) ([]string, error) {
+	var metrics []string
+	cursor := ""
+	for {
+		query := url.Values{"page[size]": []string{"10000"}}
+		if cursor != "" {
+			query.Set("page[cursor]", cursor)
+		}
+		var resp metricsResponse
+		if err := getV2(client, auth, "/api/v2/metrics", query, &resp); err != nil {
+			return nil, err
+		}
+		for _, metric := range resp.Data {
+			metrics = append(metrics, metric.ID)
+		}
+		cursor = resp.Meta.Pagination.NextCursor
+		if cursor == "" {
+			return metrics, nil
+		}
+	}
+}
+
+// MetricTagConfigurationGenerator ...
+type MetricTagConfigurationGenerator struct {
+	DatadogService
+	configs map[string]metricTagConfiguration
+}
+
+func (g *MetricTagConfigurationGenerator) createResource(config metricTagConfiguration) terraformutils.Resource {
+	return terraformutils.NewResource(
+		config.ID,
+		strings.ReplaceAll(config.ID, ".", "_"),
+		"datadog_metric_tag_configuration",
+		"datadog",
+		map[string]string{
+			"metric_name": config.ID,
+			"metric_type": config.Attributes.MetricType,
+		},
+		MetricTagConfigurationAllowEmptyValues,
+		map[string]interface{}{},
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each metric with a tag configuration create 1 TerraformResource.
+// Need Metric Name as ID for terraform resource
+func (g *MetricTagConfigurationGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	metrics, err := listMetrics(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+
+	resources := []terraformutils.Resource{}
+	g.configs = map[string]metricTagConfiguration{}
+	for _, metric := range metrics {
+		var resp metricTagConfigurationResponse
+		err := getV2(datadogClientV2, authV2, fmt.Sprintf("/api/v2/metrics/%s/tags", url.PathEscape(metric)), url.Values{}, &resp)
+		// Metrics without tag configuration have nothing to import
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		g.configs[metric] = resp.Data
+		resources = append(resources, g.createResource(resp.Data))
+	}
+	g.Resources = resources
+	return nil
+}
+
+// PostConvertHook write the tags and aggregations as returned by the API
+func (g *MetricTagConfigurationGenerator) PostConvertHook() error {
+	for _, r := range g.Resources {
+		config, ok := g.configs[r.InstanceState.ID]
+		if !ok {
+			continue
+		}
+		for key, value := range metricTagConfigurationFields(config) {
+			r.Item[key] = value
+		}
+		if len(config.Attributes.Aggregations) > 0 {
+			aggregations := make([]interface{}, 0, len(config.Attributes.Aggregations))
+			for _, aggregation := range config.Attributes.Aggregations {
+				aggregations = append(aggregations, map[string]interface{}{
+					"space": aggregation.Space,
+					"time":  aggregation.Time,
+				})
+			}
+			r.Item["aggregations"] = aggregations
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
+
+// metricTagConfigurationFields return the fields of a tag configuration to
+// keep as returned by the API. In the default include mode tags lists the
+// queryable tags, in exclude mode it lists the tags left out, so
+// exclude_tags_mode is always set explicitly to keep the tags meaning.
+func metricTagConfigurationFields(config metricTagConfiguration) map[string]interface{} {
+	excludeTagsMode := config.Attributes.ExcludeTagsMode != nil && *config.Attributes.ExcludeTagsMode
+	tags := make([]interface{}, 0, len(config.Attributes.Tags))
+	for _, tag := range config.Attributes.Tags {
+		tags = append(tags, tag)
+	}
+	return map[string]interface{}{
+		"tags":              tags,
+		"exclude_tags_mode": strconv.FormatBool(excludeTagsMode),
+	}
+}
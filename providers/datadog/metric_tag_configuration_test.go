@@ -0,0 +1,99 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMetricTagConfigurationExcludeTagsMode(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "exclude mode",
+			response: `{"id": "app.requests", "attributes": {"metric_type": "count", "tags": ["pod_name", "container_id"], "exclude_tags_mode": true}}`,
+			expected: map[string]interface{}{"tags": []interface{}{"pod_name", "container_id"}, "exclude_tags_mode": "true"},
+		},
+		{
+			name:     "include mode",
+			response: `{"id": "app.requests", "attributes": {"metric_type": "count", "tags": ["env", "service"]}}`,
+			expected: map[string]interface{}{"tags": []interface{}{"env", "service"}, "exclude_tags_mode": "false"},
+		},
+	} {
+		var config metricTagConfiguration
+		if err := json.Unmarshal([]byte(tc.response), &config); err != nil {
+			t.Fatal(err)
+		}
+		if fields := metricTagConfigurationFields(config); !reflect.DeepEqual(fields, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, fields)
+		}
+	}
+}
+
+func TestMetricTagConfigurationGenerator(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/metrics":
+			if r.URL.Query().Get("page[cursor]") == "" {
+				_, _ = w.Write([]byte(`{"data": [{"id": "app.requests", "type": "manage_tags"}], "meta": {"pagination": {"next_cursor": "next"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": [{"id": "app.latency", "type": "metrics"}], "meta": {"pagination": {"next_cursor": null}}}`))
+		case "/api/v2/metrics/app.requests/tags":
+			_, _ = w.Write([]byte(`{"data": {"id": "app.requests", "type": "manage_tags", "attributes": {"metric_type": "count", "tags": ["env"], "aggregations": [{"space": "sum", "time": "sum"}]}}}`))
+		case "/api/v2/metrics/app.latency/tags":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": ["Not found"]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	g := &MetricTagConfigurationGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Resources) != 1 {
+		t.Fatalf("expected only the configured metric, got %v", g.Resources)
+	}
+	r := g.Resources[0]
+	if r.InstanceState.ID != "app.requests" || r.ResourceName != "tfer--app_requests" {
+		t.Errorf("unexpected metric tag configuration %s %s", r.InstanceState.ID, r.ResourceName)
+	}
+	if r.InstanceState.Attributes["metric_type"] != "count" {
+		t.Errorf("expected the metric type to be set, got %v", r.InstanceState.Attributes)
+	}
+
+	g.Resources[0].Item = map[string]interface{}{"metric_name": "app.requests"}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{map[string]interface{}{"space": "sum", "time": "sum"}}
+	if aggregations := g.Resources[0].Item["aggregations"]; !reflect.DeepEqual(aggregations, expected) {
+		t.Errorf("expected aggregations %v, got %v", expected, aggregations)
+	}
+}
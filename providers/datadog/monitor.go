@@ -17,7 +17,9 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
@@ -40,6 +42,12 @@ func (g *MonitorGenerator) createResources(monitors []datadogV1.Monitor) []terra
 		if monitor.GetType() == datadogV1.MONITORTYPE_SYNTHETICS_ALERT {
 			continue
 		}
+		creator := monitor.GetCreator()
+		// The monitors list has no modified-since parameter nor sort, an
+		// incremental export lists them all and filters them here
+		if !g.isOwned(creator.GetHandle()) || !g.isModified(monitor.GetModified()) {
+			continue
+		}
 		resourceName := strconv.FormatInt(monitor.GetId(), 10)
 		resources = append(resources, g.createResource(resourceName))
 	}
@@ -88,6 +96,15 @@ func (g *MonitorGenerator) InitResources() error {
 		return nil
 	}
 
+	if query, ok := g.Args["monitor-search-query"].(string); ok && query != "" {
+		monitors, err := searchMonitors(datadogClientV1, authV1, query)
+		if err != nil {
+			return err
+		}
+		g.Resources = g.createResources(monitors)
+		return nil
+	}
+
 	monitors, _, err := datadogClientV1.MonitorsApi.ListMonitors(authV1).Execute()
 	if err != nil {
 		return err
@@ -95,3 +112,109 @@ func (g *MonitorGenerator) InitResources() error {
 	g.Resources = g.createResources(monitors)
 	return nil
 }
+
+// monitorSearchResponse is the page of monitors matching a search query
+type monitorSearchResponse struct {
+	Monitors []struct {
+		ID      int64  `json:"id"`
+		Type    string `json:"type"`
+		Creator struct {
+			Handle string `json:"handle"`
+		} `json:"creator"`
+	} `json:"monitors"`
+	Metadata struct {
+		Page      int64 `json:"page"`
+		PageCount int64 `json:"page_count"`
+	} `json:"metadata"`
+}
+
+// searchMonitors return the monitors matching query, filtered server side by
+// the monitor search endpoint instead of listing all of them
+func searchMonitors(client *datadogV1.APIClient, auth context.Context, query string) ([]datadogV1.Monitor, error) {
+	var monitors []datadogV1.Monitor
+	for page := int64(0); ; page++ {
+		var resp monitorSearchResponse
+		err := getV1(client, auth, "/api/v1/monitor/search", url.Values{
+			"query":    []string{query},
+			"page":     []string{strconv.FormatInt(page, 10)},
+			"per_page": []string{"100"},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range resp.Monitors {
+			monitor := datadogV1.Monitor{}
+			monitor.SetId(result.ID)
+			monitor.SetType(datadogV1.MonitorType(result.Type))
+			creator := datadogV1.Creator{}
+			creator.SetHandle(result.Creator.Handle)
+			monitor.SetCreator(creator)
+			monitors = append(monitors, monitor)
+		}
+		if page+1 >= resp.Metadata.PageCount {
+			return monitors, nil
+		}
+	}
+}
+
+// monitorFormulaQueries are the blocks of the variables of formula and function monitors
+var monitorFormulaQueries = []string{"cloud_cost_query", "event_query"}
+
+// PostConvertHook keeps formula monitor queries verbatim. The formula and the
+// query names it references must survive unchanged, so template sequences are
+// escaped instead of being left for terraform to interpolate.
+func (g *MonitorGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		if query, ok := r.InstanceState.Attributes["query"]; ok && query != "" {
+			g.Resources[i].Item["query"] = escapeTemplateSequences(query)
+		}
+		mapMonitorVariables(g.Resources[i].Item, r.InstanceState.Attributes)
+	}
+	return g.DatadogService.PostConvertHook()
+}
+
+// mapMonitorVariables map the data_source, name and aggregator of each query
+// of the formula from the state, and escape the queries like the formula
+func mapMonitorVariables(item map[string]interface{}, attributes map[string]string) {
+	variables, ok := item["variables"].([]interface{})
+	if !ok || len(variables) == 0 {
+		return
+	}
+	block, ok := variables[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, kind := range monitorFormulaQueries {
+		queries, _ := block[kind].([]interface{})
+		for i, q := range queries {
+			query, ok := q.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			prefix := fmt.Sprintf("variables.0.%s.%d.", kind, i)
+			for _, key := range []string{"data_source", "name", "aggregator"} {
+				if value := attributes[prefix+key]; value != "" {
+					query[key] = value
+				}
+			}
+			if value := attributes[prefix+"query"]; value != "" {
+				query["query"] = escapeTemplateSequences(value)
+			}
+			searches, _ := query["search"].([]interface{})
+			for j, s := range searches {
+				search, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if value := attributes[fmt.Sprintf("%ssearch.%d.query", prefix, j)]; value != "" {
+					search["query"] = escapeTemplateSequences(value)
+				}
+			}
+		}
+	}
+}
+
+// escapeTemplateSequences escape `${` and `%{` so terraform reads them literally
+func escapeTemplateSequences(value string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(value)
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// MonitorConfigPolicyAllowEmptyValues ...
+	MonitorConfigPolicyAllowEmptyValues = []string{}
+)
+
+type monitorConfigPoliciesResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// MonitorConfigPolicyGenerator ...
+type MonitorConfigPolicyGenerator struct {
+	DatadogService
+}
+
+func (g *MonitorConfigPolicyGenerator) createResource(policyID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		policyID,
+		fmt.Sprintf("monitor_config_policy_%s", policyID),
+		"datadog_monitor_config_policy",
+		"datadog",
+		MonitorConfigPolicyAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each monitor configuration policy create 1 TerraformResource.
+// Need Monitor Config Policy ID as ID for terraform resource
+func (g *MonitorConfigPolicyGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp monitorConfigPoliciesResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/monitor/policy", url.Values{}, &resp); err != nil {
+		return err
+	}
+	resources := []terraformutils.Resource{}
+	for _, policy := range resp.Data {
+		resources = append(resources, g.createResource(policy.ID))
+	}
+	g.Resources = resources
+	return nil
+}
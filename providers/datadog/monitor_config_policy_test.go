@@ -0,0 +1,51 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMonitorConfigPolicies(t *testing.T) {
+	policies := `{"data": [{"id": "policy-uuid", "type": "monitor-config-policy", "attributes": {"policy_type": "tag", "policy": {"tag_key": "team", "tag_key_required": true, "valid_tag_values": ["core"]}}}]}`
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/monitor/policy" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(policies))
+	}))
+
+	g := &MonitorConfigPolicyGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "policy-uuid" || g.Resources[0].InstanceInfo.Type != "datadog_monitor_config_policy" {
+		t.Fatalf("unexpected monitor config policies %v", g.Resources)
+	}
+
+	policies = `{"data": []}`
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 0 {
+		t.Errorf("expected no monitor config policy, got %v", g.Resources)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var unsafeIdentifierChars = regexp.MustCompile(`[^0-9A-Za-z_]`)
+
+// monitorGroup is a set of monitors identical but for their tag values,
+// written as a single for_each resource
+type monitorGroup struct {
+	name string
+	item map[string]interface{}
+	// tag values and id of each monitor, by for_each key
+	values map[string]map[string]string
+	ids    map[string]string
+}
+
+// groupIdenticalMonitors group the monitors differing only by the values of
+// their tags and return the groups of at least two monitors along with the
+// resources left out of them
+func groupIdenticalMonitors(resources []terraformutils.Resource) ([]monitorGroup, []terraformutils.Resource) {
+	var signatures []string
+	candidates := map[string][]int{}
+	templates := map[int]map[string]interface{}{}
+	values := map[int]map[string]string{}
+	for i, r := range resources {
+		if r.InstanceInfo.Type != "datadog_monitor" {
+			continue
+		}
+		tagValues, template := monitorTemplate(r.Item)
+		if len(tagValues) == 0 {
+			continue
+		}
+		signature, err := json.Marshal(template)
+		if err != nil {
+			continue
+		}
+		if _, exist := candidates[string(signature)]; !exist {
+			signatures = append(signatures, string(signature))
+		}
+		candidates[string(signature)] = append(candidates[string(signature)], i)
+		templates[i] = template
+		values[i] = tagValues
+	}
+
+	var groups []monitorGroup
+	grouped := map[int]bool{}
+	for _, signature := range signatures {
+		members := candidates[signature]
+		if len(members) < 2 {
+			continue
+		}
+		group := monitorGroup{
+			name:   resources[members[0]].ResourceName + "_group",
+			item:   templates[members[0]],
+			values: map[string]map[string]string{},
+			ids:    map[string]string{},
+		}
+		for _, i := range members {
+			key := strings.TrimPrefix(resources[i].ResourceName, "tfer--")
+			group.values[key] = values[i]
+			group.ids[key] = resources[i].InstanceState.ID
+			grouped[i] = true
+		}
+		groups = append(groups, group)
+	}
+
+	ungrouped := []terraformutils.Resource{}
+	for i, r := range resources {
+		if !grouped[i] {
+			ungrouped = append(ungrouped, r)
+		}
+	}
+	return groups, ungrouped
+}
+
+// monitorTemplate return the tag values of a monitor and a copy of its item
+// where each `key:value` tag is replaced by `key:${each.value.key}`
+func monitorTemplate(item map[string]interface{}) (map[string]string, map[string]interface{}) {
+	tags, _ := item["tags"].([]interface{})
+	tagValues := map[string]string{}
+	var tagPatterns []*regexp.Regexp
+	var tagReplacements []string
+	for _, tag := range tags {
+		parts := strings.SplitN(fmt.Sprint(tag), ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			continue
+		}
+		key := unsafeIdentifierChars.ReplaceAllString(parts[0], "_")
+		if _, exist := tagValues[key]; exist {
+			continue
+		}
+		tagValues[key] = parts[1]
+		// the tag must not be followed by characters which would make it another tag value
+		tagPatterns = append(tagPatterns, regexp.MustCompile(regexp.QuoteMeta(parts[0]+":"+parts[1])+`([^0-9A-Za-z_.:/\-]|$)`))
+		tagReplacements = append(tagReplacements, parts[0]+":${each.value."+key+"}")
+	}
+
+	var templatize func(value interface{}) interface{}
+	templatize = func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case string:
+			for i, pattern := range tagPatterns {
+				replacement := tagReplacements[i]
+				v = pattern.ReplaceAllStringFunc(v, func(match string) string {
+					return replacement + pattern.FindStringSubmatch(match)[1]
+				})
+			}
+			return v
+		case []interface{}:
+			values := make([]interface{}, len(v))
+			for i := range v {
+				values[i] = templatize(v[i])
+			}
+			return values
+		case map[string]interface{}:
+			values := make(map[string]interface{}, len(v))
+			for key := range v {
+				values[key] = templatize(v[key])
+			}
+			return values
+		default:
+			return v
+		}
+	}
+	return tagValues, templatize(item).(map[string]interface{})
+}
+
+// writeMonitorGroups write the for_each resource and the locals of each
+// group to monitor_groups.tf.json, the JSON syntax keeping the locals maps
+// as they are, and the commands importing the monitors into the groups
+func writeMonitorGroups(path string, groups []monitorGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	locals := map[string]interface{}{}
+	monitors := map[string]interface{}{}
+	var imports []string
+	for _, group := range groups {
+		locals[group.name] = group.values
+		item := map[string]interface{}{"for_each": "${local." + group.name + "}"}
+		for key, value := range group.item {
+			item[key] = value
+		}
+		monitors[group.name] = item
+
+		keys := make([]string, 0, len(group.ids))
+		for key := range group.ids {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			imports = append(imports, fmt.Sprintf(`terraform import 'datadog_monitor.%s["%s"]' %s`, group.name, key, group.ids[key]))
+		}
+	}
+
+	var groupsFile bytes.Buffer
+	encoder := json.NewEncoder(&groupsFile)
+	// Monitor queries compare with > and <, keep them readable
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(map[string]interface{}{
+		"locals":   locals,
+		"resource": map[string]interface{}{"datadog_monitor": monitors},
+	})
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+"/monitor_groups.tf.json", groupsFile.Bytes(), os.ModePerm); err != nil {
+		return err
+	}
+	// for_each instances can't be written to the exported state, they are imported afterwards
+	script := "#!/bin/sh\nset -e\n" + strings.Join(imports, "\n") + "\n"
+	return ioutil.WriteFile(path+"/monitor_groups_import.sh", []byte(script), os.ModePerm)
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestGroupIdenticalMonitors(t *testing.T) {
+	newMonitor := func(id, env string) terraformutils.Resource {
+		r := terraformutils.NewSimpleResource(id, "monitor_"+id, "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+		r.Item = map[string]interface{}{
+			"name":    "High CPU",
+			"type":    "metric alert",
+			"query":   "avg(last_5m):avg:system.cpu.user{env:" + env + "} > 90",
+			"message": "CPU is high on {{host.name}} @slack-ops",
+			"tags":    []interface{}{"env:" + env, "team:core"},
+		}
+		return r
+	}
+	different := newMonitor("4", "prod")
+	different.Item["type"] = "query alert"
+	resources := []terraformutils.Resource{
+		newMonitor("1", "prod"),
+		newMonitor("2", "staging"),
+		newMonitor("3", "production"),
+		different,
+	}
+
+	groups, ungrouped := groupIdenticalMonitors(resources)
+	if len(groups) != 1 {
+		t.Fatalf("expected a single group, got %v", groups)
+	}
+	if len(ungrouped) != 1 || ungrouped[0].InstanceState.ID != "4" {
+		t.Errorf("expected the monitor of another type to be left out, got %v", ungrouped)
+	}
+
+	group := groups[0]
+	if group.item["query"] != "avg(last_5m):avg:system.cpu.user{env:${each.value.env}} > 90" {
+		t.Errorf("unexpected query template %v", group.item["query"])
+	}
+	expectedValues := map[string]map[string]string{
+		"monitor_1": {"env": "prod", "team": "core"},
+		"monitor_2": {"env": "staging", "team": "core"},
+		"monitor_3": {"env": "production", "team": "core"},
+	}
+	if !reflect.DeepEqual(group.values, expectedValues) {
+		t.Errorf("expected for_each values %v, got %v", expectedValues, group.values)
+	}
+
+	path := t.TempDir()
+	if err := writeMonitorGroups(path, groups); err != nil {
+		t.Fatal(err)
+	}
+	groupsFile, err := ioutil.ReadFile(path + "/monitor_groups.tf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		Resource struct {
+			DatadogMonitor map[string]map[string]interface{} `json:"datadog_monitor"`
+		} `json:"resource"`
+	}
+	if err := json.Unmarshal(groupsFile, &config); err != nil {
+		t.Fatal(err)
+	}
+	monitor, ok := config.Resource.DatadogMonitor["tfer--monitor_1_group"]
+	if !ok || monitor["for_each"] != "${local.tfer--monitor_1_group}" {
+		t.Errorf("expected a for_each monitor resource:\n%s", groupsFile)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// MonitorJSONAllowEmptyValues ...
+	MonitorJSONAllowEmptyValues = []string{}
+
+	// monitorJSONComputedFields list the monitor fields set by Datadog, left
+	// out of the monitor JSON so the next plan shows no diff
+	monitorJSONComputedFields = []string{
+		"created", "created_at", "creator", "deleted", "id", "matching_downtimes",
+		"modified", "multi", "org_id", "overall_state", "overall_state_modified", "state",
+	}
+)
+
+// MonitorJSONGenerator ...
+type MonitorJSONGenerator struct {
+	DatadogService
+	monitors map[string]string
+}
+
+// createResources create a resource from each raw monitor, keeping its JSON
+// without the computed fields
+func (g *MonitorJSONGenerator) createResources(rawMonitors []json.RawMessage) ([]terraformutils.Resource, error) {
+	resources := []terraformutils.Resource{}
+	g.monitors = map[string]string{}
+	for _, rawMonitor := range rawMonitors {
+		monitor := map[string]interface{}{}
+		decoder := json.NewDecoder(bytes.NewReader(rawMonitor))
+		// Keep the numbers as written, monitor ids overflow a float64 precision
+		decoder.UseNumber()
+		if err := decoder.Decode(&monitor); err != nil {
+			return nil, err
+		}
+		if monitor["type"] == string(datadogV1.MONITORTYPE_SYNTHETICS_ALERT) {
+			continue
+		}
+		monitorID := fmt.Sprint(monitor["id"])
+		for _, field := range monitorJSONComputedFields {
+			delete(monitor, field)
+		}
+		var document bytes.Buffer
+		encoder := json.NewEncoder(&document)
+		// Monitor queries compare with > and <, keep them readable
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(monitor); err != nil {
+			return nil, err
+		}
+		g.monitors[monitorID] = string(bytes.TrimSpace(document.Bytes()))
+		resources = append(resources, g.createResource(monitorID))
+	}
+
+	return resources, nil
+}
+
+func (g *MonitorJSONGenerator) createResource(monitorID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		monitorID,
+		fmt.Sprintf("monitor_json_%s", monitorID),
+		"datadog_monitor_json",
+		"datadog",
+		MonitorJSONAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each monitor create 1 TerraformResource holding the monitor JSON.
+// Need Monitor ID as ID for terraform resource
+func (g *MonitorJSONGenerator) InitResources() error {
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	var rawMonitors []json.RawMessage
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && (filter.IsApplicable("monitor_json") || filter.IsApplicable("monitor")) {
+			for _, value := range filter.AcceptableValues {
+				var rawMonitor json.RawMessage
+				if err := getV1(datadogClientV1, authV1, "/api/v1/monitor/"+url.PathEscape(value), url.Values{}, &rawMonitor); err != nil {
+					return err
+				}
+				rawMonitors = append(rawMonitors, rawMonitor)
+			}
+		}
+	}
+
+	if len(rawMonitors) == 0 {
+		if err := getV1(datadogClientV1, authV1, "/api/v1/monitor", url.Values{}, &rawMonitors); err != nil {
+			return err
+		}
+	}
+	resources, err := g.createResources(rawMonitors)
+	if err != nil {
+		return err
+	}
+	g.Resources = resources
+	return nil
+}
+
+// PostConvertHook write the monitor JSON as returned by the API, without its
+// computed fields, in a heredoc
+func (g *MonitorJSONGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		document, ok := g.monitors[r.InstanceState.ID]
+		if !ok {
+			continue
+		}
+		g.Resources[i].Item["monitor"] = fmt.Sprintf(`<<EOF
+%s
+EOF`, escapeTemplateSequences(document))
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMonitorJSONRoundTrip(t *testing.T) {
+	authored := `{
+		"name": "Checkout errors",
+		"type": "query alert",
+		"query": "sum(last_5m):sum:trace.http.request.errors{service:checkout}.as_count() > 10",
+		"message": "Errors on ${service} {{#is_alert}}@pagerduty-checkout{{/is_alert}}",
+		"tags": ["team:checkout", "env:prod"],
+		"priority": 2,
+		"restricted_roles": ["00000000-0000-1111-0000-000000000000"],
+		"options": {
+			"thresholds": {"critical": 10, "warning": 5.5},
+			"notify_no_data": false,
+			"renotify_statuses": ["alert", "no data"],
+			"scheduling_options": {"evaluation_window": {"day_starts": "04:00"}},
+			"new_group_delay": 60
+		}
+	}`
+	computed := `"id": 9007199254740993, "created": "2024-01-01T00:00:00.000Z", "modified": "2024-01-02T00:00:00.000Z",
+		"creator": {"handle": "jane@example.com"}, "overall_state": "OK", "org_id": 2, "deleted": null, "multi": false,`
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/monitor" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{` + computed + strings.TrimPrefix(strings.TrimSpace(authored), "{") + `]`))
+	}))
+
+	g := &MonitorJSONGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "9007199254740993" || g.Resources[0].InstanceInfo.Type != "datadog_monitor_json" {
+		t.Fatalf("unexpected monitors %v", g.Resources)
+	}
+	g.Resources[0].Item = map[string]interface{}{}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	heredoc, _ := g.Resources[0].Item["monitor"].(string)
+	if !strings.HasPrefix(heredoc, "<<EOF\n") || !strings.HasSuffix(heredoc, "\nEOF") {
+		t.Fatalf("expected the monitor JSON in a heredoc, got %s", heredoc)
+	}
+	var exported, expected interface{}
+	if err := json.Unmarshal([]byte(heredocDocument(heredoc)), &exported); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(authored), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exported, expected) {
+		t.Errorf("expected the authored monitor\n%v\ngot\n%v", expected, exported)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// monitorGroupTagKeys list the tag keys whose values group the monitors
+var monitorGroupTagKeys = []string{"service", "team"}
+
+// inferMonitorTagGroups return the references to the monitors id sharing a
+// value of each group tag key, by tag key then tag value
+func inferMonitorTagGroups(resources []terraformutils.Resource) map[string]map[string][]string {
+	groups := map[string]map[string][]string{}
+	for _, r := range resources {
+		if r.InstanceInfo.Type != "datadog_monitor" {
+			continue
+		}
+		for _, tagKey := range monitorGroupTagKeys {
+			value := resourceTagValue(r, tagKey)
+			if value == "" {
+				continue
+			}
+			if groups[tagKey] == nil {
+				groups[tagKey] = map[string][]string{}
+			}
+			groups[tagKey][value] = append(groups[tagKey][value], "${datadog_monitor."+r.ResourceName+".id}")
+		}
+	}
+	for _, values := range groups {
+		for _, references := range values {
+			sort.Strings(references)
+		}
+	}
+	return groups
+}
+
+// writeMonitorTagGroups write the monitor_tag_groups local to
+// monitor_tag_groups.tf.json, the JSON syntax keeping any tag value as a key
+func writeMonitorTagGroups(path string, groups map[string]map[string][]string) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
+
+	var groupsFile bytes.Buffer
+	encoder := json.NewEncoder(&groupsFile)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(map[string]interface{}{
+		"locals": map[string]interface{}{"monitor_tag_groups": groups},
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path+"/monitor_tag_groups.tf.json", groupsFile.Bytes(), os.ModePerm)
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestMonitorTagGroups(t *testing.T) {
+	newMonitor := func(id string, tags ...string) terraformutils.Resource {
+		attributes := map[string]string{"id": id}
+		for i, tag := range tags {
+			attributes["tags."+string(rune('0'+i))] = tag
+		}
+		return terraformutils.NewResource(id, "monitor_"+id, "datadog_monitor", "datadog", attributes, MonitorAllowEmptyValues, map[string]interface{}{})
+	}
+	first := newMonitor("1", "service:checkout", "env:prod")
+	second := newMonitor("2", "service:checkout", "team:payments")
+	other := newMonitor("3", "service:search")
+	untagged := newMonitor("4")
+
+	groups := inferMonitorTagGroups([]terraformutils.Resource{second, first, other, untagged})
+
+	path := t.TempDir()
+	if err := writeMonitorTagGroups(path, groups); err != nil {
+		t.Fatal(err)
+	}
+	groupsFile, err := ioutil.ReadFile(path + "/monitor_tag_groups.tf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		Locals struct {
+			MonitorTagGroups map[string]map[string][]string `json:"monitor_tag_groups"`
+		} `json:"locals"`
+	}
+	if err := json.Unmarshal(groupsFile, &config); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]map[string][]string{
+		"service": {
+			"checkout": {"${datadog_monitor." + first.ResourceName + ".id}", "${datadog_monitor." + second.ResourceName + ".id}"},
+			"search":   {"${datadog_monitor." + other.ResourceName + ".id}"},
+		},
+		"team": {
+			"payments": {"${datadog_monitor." + second.ResourceName + ".id}"},
+		},
+	}
+	if !reflect.DeepEqual(config.Locals.MonitorTagGroups, expected) {
+		t.Errorf("expected %v, got %v", expected, config.Locals.MonitorTagGroups)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestMonitorFormulaQueries(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/monitor" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": 1, "type": "query alert", "query": "formula(\"query1 / query2 * 100\").last(\"5m\") > 90"}]`))
+	}))
+	g := &MonitorGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"datadogClientV1": client,
+		"authV1":          auth,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 {
+		t.Fatalf("expected 1 monitor, got %d", len(g.Resources))
+	}
+
+	// as refreshed by the provider
+	query := `formula("query1 / query2 * 100").last("5m") > 90`
+	g.Resources[0].InstanceState.Attributes = map[string]string{
+		"id":                             "1",
+		"query":                          query,
+		"variables.#":                    "1",
+		"variables.0.cloud_cost_query.#": "2",
+		"variables.0.cloud_cost_query.0.data_source": "cloud_cost",
+		"variables.0.cloud_cost_query.0.name":        "query1",
+		"variables.0.cloud_cost_query.0.aggregator":  "sum",
+		"variables.0.cloud_cost_query.0.query":       "sum:aws.cost.amortized{*}.rollup(sum, daily)",
+		"variables.0.cloud_cost_query.1.data_source": "cloud_cost",
+		"variables.0.cloud_cost_query.1.name":        "query2",
+		"variables.0.cloud_cost_query.1.aggregator":  "avg",
+		"variables.0.cloud_cost_query.1.query":       "sum:aws.cost.net{service:${service}}",
+		"variables.0.event_query.#":                  "0",
+	}
+	parseTestState(t, &g.Resources[0], cty.Object(map[string]cty.Type{
+		"query": cty.String,
+		"variables": cty.List(cty.Object(map[string]cty.Type{
+			"cloud_cost_query": cty.List(cty.Object(map[string]cty.Type{
+				"data_source": cty.String,
+				"name":        cty.String,
+				"aggregator":  cty.String,
+				"query":       cty.String,
+			})),
+			"event_query": cty.List(cty.Object(map[string]cty.Type{
+				"data_source": cty.String,
+				"name":        cty.String,
+				"search": cty.List(cty.Object(map[string]cty.Type{
+					"query": cty.String,
+				})),
+			})),
+		})),
+	}))
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Resources[0].Item["query"] != query {
+		t.Errorf("formula was mangled: %v", g.Resources[0].Item["query"])
+	}
+	expected := []interface{}{
+		map[string]interface{}{"data_source": "cloud_cost", "name": "query1", "aggregator": "sum", "query": "sum:aws.cost.amortized{*}.rollup(sum, daily)"},
+		map[string]interface{}{"data_source": "cloud_cost", "name": "query2", "aggregator": "avg", "query": "sum:aws.cost.net{service:$${service}}"},
+	}
+	variables := g.Resources[0].Item["variables"].([]interface{})[0].(map[string]interface{})
+	if queries := variables["cloud_cost_query"]; !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected formula queries %v, got %v", expected, queries)
+	}
+}
+
+func TestEscapeTemplateSequences(t *testing.T) {
+	if escaped := escapeTemplateSequences(`logs("service:${foo} %{bar}")`); escaped != `logs("service:$${foo} %%{bar}")` {
+		t.Errorf("failed to escape template sequences: %s", escaped)
+	}
+}
+
+func TestMonitorRestrictedRolesReferences(t *testing.T) {
+	monitor := terraformutils.NewSimpleResource("1", "monitor_1", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	monitor.Item = map[string]interface{}{
+		"restricted_roles": []interface{}{"team-uuid", "role-uuid", "unknown-uuid"},
+	}
+	team := terraformutils.NewResource("team-uuid", "team_1", "datadog_team", "datadog", map[string]string{"id": "team-uuid"}, []string{}, map[string]interface{}{})
+	role := terraformutils.NewResource("role-uuid", "role_1", "datadog_role", "datadog", map[string]string{"id": "role-uuid"}, RoleAllowEmptyValues, map[string]interface{}{})
+
+	importedResource := terraformutils.ConnectServices(map[string][]terraformutils.Resource{
+		"monitor": {monitor},
+		"team":    {team},
+		"role":    {role},
+	}, false, DatadogProvider{}.GetResourceConnections())
+
+	expected := []interface{}{
+		"${data.terraform_remote_state.local.outputs.datadog_team_tfer--team_1_id}",
+		"${data.terraform_remote_state.local.outputs.datadog_role_tfer--role_1_id}",
+		"unknown-uuid",
+	}
+	if restrictedRoles := importedResource["monitor"][0].Item["restricted_roles"]; !reflect.DeepEqual(restrictedRoles, expected) {
+		t.Errorf("expected restricted_roles %v, got %v", expected, restrictedRoles)
+	}
+}
+
+func TestMonitorIgnoreChanges(t *testing.T) {
+	ignoreChanges, err := parseIgnoreChanges("datadog_monitor:renotify_interval")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitor := terraformutils.NewSimpleResource("1", "monitor_1", "datadog_monitor", "datadog", MonitorAllowEmptyValues)
+	monitor.Item = map[string]interface{}{"name": "cpu"}
+	g := &MonitorGenerator{}
+	g.Resources = []terraformutils.Resource{monitor}
+	g.SetArgs(map[string]interface{}{"ignore-changes": ignoreChanges})
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"ignore_changes": []interface{}{"overall_state", "renotify_interval"}},
+	}
+	if lifecycle := g.Resources[0].Item["lifecycle"]; !reflect.DeepEqual(lifecycle, expected) {
+		t.Errorf("expected lifecycle %v, got %v", expected, lifecycle)
+	}
+
+	hcl, err := terraformutils.HclPrintResource(g.Resources, map[string]interface{}{}, "hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(hcl), "lifecycle {") {
+		t.Errorf("lifecycle block not printed:\n%s", hcl)
+	}
+}
+
+func TestMonitorSearchQuery(t *testing.T) {
+	query := `tag:"team:core" status:alert`
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/monitor/search" || r.URL.Query().Get("query") != query {
+			t.Errorf("unexpected search request %s", r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "0":
+			_, _ = w.Write([]byte(`{"monitors": [{"id": 1, "type": "query alert"}, {"id": 2, "type": "synthetics alert"}], "metadata": {"page": 0, "page_count": 2}}`))
+		case "1":
+			_, _ = w.Write([]byte(`{"monitors": [{"id": 3, "type": "log alert"}], "metadata": {"page": 1, "page_count": 2}}`))
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+
+	g := &MonitorGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"datadogClientV1":      client,
+		"authV1":               auth,
+		"monitor-search-query": query,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, r := range g.Resources {
+		ids = append(ids, r.InstanceState.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "3"}) {
+		t.Errorf("expected the matching monitors 1 and 3, got %v", ids)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// OnCallEscalationPolicyAllowEmptyValues ...
+	OnCallEscalationPolicyAllowEmptyValues = []string{}
+)
+
+type escalationPolicyResponse struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	Included []struct {
+		ID            string `json:"id"`
+		Type          string `json:"type"`
+		Relationships struct {
+			Targets struct {
+				Data []struct {
+					ID   string `json:"id"`
+					Type string `json:"type"`
+				} `json:"data"`
+			} `json:"targets"`
+		} `json:"relationships"`
+	} `json:"included"`
+}
+
+// onCallEscalationPolicyIDs return the escalation policies the routing rules
+// of the teams escalate to, the API has no listing of the escalation policies
+func onCallEscalationPolicyIDs(client *datadogV2.APIClient, auth context.Context) ([]string, error) {
+	teams, err := listTeams(client, auth)
+	if err != nil {
+		return nil, err
+	}
+	policies := map[string]bool{}
+	for _, t := range teams {
+		var resp teamRoutingRulesResponse
+		err := getV2(client, auth, fmt.Sprintf("/api/v2/on-call/teams/%s/routing-rules", url.PathEscape(t.ID)), url.Values{
+			"include": []string{"rules"},
+		}, &resp)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range resp.Included {
+			if policy := rule.Relationships.Policy.Data; policy != nil {
+				policies[policy.ID] = true
+			}
+		}
+	}
+	ids := make([]string, 0, len(policies))
+	for id := range policies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// onCallScheduleIDs return the schedules the steps of the escalation policies
+// target, the API has no listing of the schedules
+func onCallScheduleIDs(client *datadogV2.APIClient, auth context.Context, policyIDs []string) ([]string, error) {
+	schedules := map[string]bool{}
+	for _, policyID := range policyIDs {
+		var resp escalationPolicyResponse
+		err := getV2(client, auth, "/api/v2/on-call/escalation-policies/"+url.PathEscape(policyID), url.Values{
+			"include": []string{"steps.targets"},
+		}, &resp)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range resp.Included {
+			if step.Type != "steps" {
+				continue
+			}
+			for _, target := range step.Relationships.Targets.Data {
+				if target.Type == "schedules" {
+					schedules[target.ID] = true
+				}
+			}
+		}
+	}
+	ids := make([]string, 0, len(schedules))
+	for id := range schedules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// OnCallEscalationPolicyGenerator ...
+type OnCallEscalationPolicyGenerator struct {
+	DatadogService
+}
+
+func (g *OnCallEscalationPolicyGenerator) createResource(policyID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		policyID,
+		fmt.Sprintf("on_call_escalation_policy_%s", policyID),
+		"datadog_on_call_escalation_policy",
+		"datadog",
+		OnCallEscalationPolicyAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each escalation policy routed to by a team create 1 TerraformResource.
+// Need Escalation Policy ID as ID for terraform resource
+func (g *OnCallEscalationPolicyGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("on_call_escalation_policy") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	policyIDs, err := onCallEscalationPolicyIDs(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	for _, policyID := range policyIDs {
+		resources = append(resources, g.createResource(policyID))
+	}
+	g.Resources = resources
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestOnCallEscalationPolicyStepTargets(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/team":
+			_, _ = w.Write([]byte(`{"data": [{"id": "team-1", "type": "team", "attributes": {"name": "Team 1", "handle": "team-1"}}]}`))
+		case "/api/v2/on-call/teams/team-1/routing-rules":
+			_, _ = w.Write([]byte(`{"data": {"id": "team-1", "type": "team_routing_rules", "relationships": {"rules": {"data": [
+				{"id": "rule-1", "type": "team_routing_rules"}
+			]}}}, "included": [
+				{"id": "rule-1", "type": "team_routing_rules", "attributes": {"urgency": "high"},
+					"relationships": {"policy": {"data": {"id": "policy-1", "type": "policies"}}}}
+			]}`))
+		case "/api/v2/on-call/escalation-policies/policy-1":
+			if r.URL.Query().Get("include") != "steps.targets" {
+				t.Errorf("expected the step targets to be included, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data": {"id": "policy-1", "type": "policies"}, "included": [
+				{"id": "step-1", "type": "steps", "relationships": {"targets": {"data": [
+					{"id": "schedule-1", "type": "schedules"},
+					{"id": "user-1", "type": "users"}
+				]}}},
+				{"id": "schedule-1", "type": "schedules"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	args := map[string]interface{}{
+		"datadogClientV2": client,
+		"authV2":          auth,
+	}
+
+	policies := &OnCallEscalationPolicyGenerator{}
+	policies.SetArgs(args)
+	if err := policies.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(policies.Resources) != 1 || policies.Resources[0].InstanceState.ID != "policy-1" {
+		t.Fatalf("expected the escalation policy of the routing rules, got %v", policies.Resources)
+	}
+	schedules := &OnCallScheduleGenerator{}
+	schedules.SetArgs(args)
+	if err := schedules.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(schedules.Resources) != 1 || schedules.Resources[0].InstanceState.ID != "schedule-1" {
+		t.Fatalf("expected the schedule targeted by the policy, got %v", schedules.Resources)
+	}
+
+	policy := policies.Resources[0]
+	policy.Item = map[string]interface{}{
+		"name": "primary",
+		"step": []interface{}{
+			map[string]interface{}{
+				"escalate_after_seconds": "300",
+				"target": []interface{}{
+					map[string]interface{}{"schedule": "schedule-1"},
+					map[string]interface{}{"user": "user-1"},
+				},
+			},
+		},
+	}
+	schedule := schedules.Resources[0]
+	schedule.InstanceState.Attributes = map[string]string{"id": "schedule-1"}
+
+	importedResource := terraformutils.ConnectServices(map[string][]terraformutils.Resource{
+		"on_call_escalation_policy": {policy},
+		"on_call_schedule":          {schedule},
+	}, true, DatadogProvider{}.GetResourceConnections())
+
+	step := importedResource["on_call_escalation_policy"][0].Item["step"].([]interface{})[0].(map[string]interface{})
+	expected := []interface{}{
+		map[string]interface{}{"schedule": "${data.terraform_remote_state.on_call_schedule.outputs.datadog_on_call_schedule_tfer--on_call_schedule_schedule-002D-1_id}"},
+		map[string]interface{}{"user": "user-1"},
+	}
+	if !reflect.DeepEqual(step["target"], expected) {
+		t.Errorf("expected step targets %v, got %v", expected, step["target"])
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// OnCallScheduleAllowEmptyValues ...
+	OnCallScheduleAllowEmptyValues = []string{}
+)
+
+// OnCallScheduleGenerator ...
+type OnCallScheduleGenerator struct {
+	DatadogService
+}
+
+func (g *OnCallScheduleGenerator) createResource(scheduleID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		scheduleID,
+		fmt.Sprintf("on_call_schedule_%s", scheduleID),
+		"datadog_on_call_schedule",
+		"datadog",
+		OnCallScheduleAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each schedule targeted by an escalation policy create 1 TerraformResource.
+// Need Schedule ID as ID for terraform resource
+func (g *OnCallScheduleGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("on_call_schedule") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	policyIDs, err := onCallEscalationPolicyIDs(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	scheduleIDs, err := onCallScheduleIDs(datadogClientV2, authV2, policyIDs)
+	if err != nil {
+		return err
+	}
+	for _, scheduleID := range scheduleIDs {
+		resources = append(resources, g.createResource(scheduleID))
+	}
+	g.Resources = resources
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// OnCallTeamRoutingRulesAllowEmptyValues ...
+	OnCallTeamRoutingRulesAllowEmptyValues = []string{}
+)
+
+type teamRoutingRule struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Query   string `json:"query"`
+		Urgency string `json:"urgency"`
+		Actions []struct {
+			Type      string `json:"type"`
+			Channel   string `json:"channel"`
+			Workspace string `json:"workspace"`
+			Team      string `json:"team"`
+			Tenant    string `json:"tenant"`
+		} `json:"actions"`
+		TimeRestriction *struct {
+			TimeZone     string `json:"time_zone"`
+			Restrictions []struct {
+				StartDay  string `json:"start_day"`
+				StartTime string `json:"start_time"`
+				EndDay    string `json:"end_day"`
+				EndTime   string `json:"end_time"`
+			} `json:"restrictions"`
+		} `json:"time_restriction"`
+	} `json:"attributes"`
+	Relationships struct {
+		Policy struct {
+			Data *struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"policy"`
+	} `json:"relationships"`
+}
+
+type teamRoutingRulesResponse struct {
+	Data struct {
+		ID            string `json:"id"`
+		Relationships struct {
+			Rules struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			} `json:"rules"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Included []teamRoutingRule `json:"included"`
+}
+
+// OnCallTeamRoutingRulesGenerator ...
+type OnCallTeamRoutingRulesGenerator struct {
+	DatadogService
+}
+
+func (g *OnCallTeamRoutingRulesGenerator) createResource(teamID string, rules []interface{}) terraformutils.Resource {
+	return terraformutils.NewResource(
+		teamID,
+		fmt.Sprintf("on_call_team_routing_rules_%s", teamID),
+		"datadog_on_call_team_routing_rules",
+		"datadog",
+		map[string]string{
+			"id": teamID,
+		},
+		OnCallTeamRoutingRulesAllowEmptyValues,
+		map[string]interface{}{
+			// id is the team the rules are routed for, referenced once connected
+			"id":   teamID,
+			"rule": rules,
+		},
+	)
+}
+
+// teamRoutingRules return the rule blocks of resp, in the order they are
+// evaluated: the order of the rules relationship, not of the included rules
+func teamRoutingRules(resp teamRoutingRulesResponse) []interface{} {
+	included := map[string]teamRoutingRule{}
+	for _, rule := range resp.Included {
+		included[rule.ID] = rule
+	}
+	rules := []interface{}{}
+	for _, ref := range resp.Data.Relationships.Rules.Data {
+		rule, ok := included[ref.ID]
+		if !ok {
+			continue
+		}
+		block := map[string]interface{}{}
+		if rule.Attributes.Query != "" {
+			block["query"] = rule.Attributes.Query
+		}
+		if rule.Attributes.Urgency != "" {
+			block["urgency"] = rule.Attributes.Urgency
+		}
+		if policy := rule.Relationships.Policy.Data; policy != nil {
+			block["escalation_policy"] = policy.ID
+		}
+		actions := []interface{}{}
+		for _, action := range rule.Attributes.Actions {
+			switch action.Type {
+			case "send_slack_message":
+				actions = append(actions, map[string]interface{}{"send_slack_message": []interface{}{
+					map[string]interface{}{"channel": action.Channel, "workspace": action.Workspace},
+				}})
+			case "send_teams_message":
+				actions = append(actions, map[string]interface{}{"send_teams_message": []interface{}{
+					map[string]interface{}{"channel": action.Channel, "team": action.Team, "tenant": action.Tenant},
+				}})
+			}
+		}
+		if len(actions) > 0 {
+			block["action"] = actions
+		}
+		if restriction := rule.Attributes.TimeRestriction; restriction != nil {
+			restrictions := []interface{}{}
+			for _, r := range restriction.Restrictions {
+				restrictions = append(restrictions, map[string]interface{}{
+					"start_day":  r.StartDay,
+					"start_time": r.StartTime,
+					"end_day":    r.EndDay,
+					"end_time":   r.EndTime,
+				})
+			}
+			block["time_restrictions"] = []interface{}{map[string]interface{}{
+				"time_zone":   restriction.TimeZone,
+				"restriction": restrictions,
+			}}
+		}
+		rules = append(rules, block)
+	}
+	return rules
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each team with routing rules create 1 TerraformResource.
+// Need Team ID as ID for terraform resource
+func (g *OnCallTeamRoutingRulesGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	teams, err := listTeams(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+
+	resources := []terraformutils.Resource{}
+	for _, t := range teams {
+		var resp teamRoutingRulesResponse
+		err := getV2(datadogClientV2, authV2, fmt.Sprintf("/api/v2/on-call/teams/%s/routing-rules", url.PathEscape(t.ID)), url.Values{
+			"include": []string{"rules"},
+		}, &resp)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// Teams without routing rules have nothing to import
+		if len(resp.Data.Relationships.Rules.Data) == 0 {
+			continue
+		}
+		resources = append(resources, g.createResource(t.ID, teamRoutingRules(resp)))
+	}
+	g.Resources = resources
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestOnCallTeamRoutingRules(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/team":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "team-1", "type": "team", "attributes": {"name": "Team 1", "handle": "team-1"}},
+				{"id": "team-2", "type": "team", "attributes": {"name": "Team 2", "handle": "team-2"}}
+			]}`))
+		case "/api/v2/on-call/teams/team-1/routing-rules":
+			if r.URL.Query().Get("include") != "rules" {
+				t.Errorf("expected the rules to be included, got %s", r.URL.RawQuery)
+			}
+			// the included rules aren't in the evaluation order
+			_, _ = w.Write([]byte(`{"data": {"id": "team-1", "type": "team_routing_rules", "relationships": {"rules": {"data": [
+				{"id": "rule-1", "type": "team_routing_rules"},
+				{"id": "rule-2", "type": "team_routing_rules"}
+			]}}}, "included": [
+				{"id": "rule-2", "type": "team_routing_rules", "attributes": {"query": "", "urgency": "low",
+					"actions": [{"type": "send_slack_message", "channel": "C0123", "workspace": "T0123"}]},
+					"relationships": {"policy": {"data": {"id": "policy-1", "type": "policies"}}}},
+				{"id": "rule-1", "type": "team_routing_rules", "attributes": {"query": "tags.service:web", "urgency": "high",
+					"time_restriction": {"time_zone": "Europe/Paris", "restrictions": [
+						{"start_day": "monday", "start_time": "09:00:00", "end_day": "friday", "end_time": "18:00:00"}
+					]}},
+					"relationships": {"policy": {"data": {"id": "policy-1", "type": "policies"}}}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	g := &OnCallTeamRoutingRulesGenerator{}
+	g.Args = map[string]interface{}{
+		"datadogClientV2": client,
+		"authV2":          auth,
+	}
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 {
+		t.Fatalf("expected 1 routing rules resource, got %d", len(g.Resources))
+	}
+	if g.Resources[0].InstanceState.ID != "team-1" || g.Resources[0].InstanceInfo.Type != "datadog_on_call_team_routing_rules" {
+		t.Errorf("unexpected resource %v", g.Resources[0].InstanceInfo)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"query":             "tags.service:web",
+			"urgency":           "high",
+			"escalation_policy": "policy-1",
+			"time_restrictions": []interface{}{map[string]interface{}{
+				"time_zone": "Europe/Paris",
+				"restriction": []interface{}{map[string]interface{}{
+					"start_day": "monday", "start_time": "09:00:00", "end_day": "friday", "end_time": "18:00:00",
+				}},
+			}},
+		},
+		map[string]interface{}{
+			"urgency":           "low",
+			"escalation_policy": "policy-1",
+			"action": []interface{}{map[string]interface{}{"send_slack_message": []interface{}{
+				map[string]interface{}{"channel": "C0123", "workspace": "T0123"},
+			}}},
+		},
+	}
+	if rules := g.Resources[0].AdditionalFields["rule"]; !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected the rules in their evaluation order %v, got %v", expected, rules)
+	}
+
+	routingRules := g.Resources[0]
+	routingRules.Item = map[string]interface{}{}
+	for key, value := range routingRules.AdditionalFields {
+		routingRules.Item[key] = value
+	}
+	team := terraformutils.NewResource("team-1", "team_1", "datadog_team", "datadog", map[string]string{"id": "team-1"}, []string{}, map[string]interface{}{})
+	importedResource := terraformutils.ConnectServices(map[string][]terraformutils.Resource{
+		"on_call_team_routing_rules": {routingRules},
+		"team":                       {team},
+	}, false, DatadogProvider{}.GetResourceConnections())
+	if id := importedResource["on_call_team_routing_rules"][0].Item["id"]; id != "${data.terraform_remote_state.local.outputs.datadog_team_tfer--team_1_id}" {
+		t.Errorf("expected the routing rules to reference the team, got %v", id)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// OrganizationSettingsAllowEmptyValues ...
+	OrganizationSettingsAllowEmptyValues = []string{}
+)
+
+type organization struct {
+	PublicID string `json:"public_id"`
+}
+
+// currentOrgPublicID return the public id of the organization the keys
+// belong to, from the organization of the current user
+func currentOrgPublicID(client *datadogV2.APIClient, auth context.Context) (string, error) {
+	var resp struct {
+		Data struct {
+			Relationships struct {
+				Org struct {
+					Data struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				} `json:"org"`
+			} `json:"relationships"`
+		} `json:"data"`
+		Included []struct {
+			ID         string `json:"id"`
+			Type       string `json:"type"`
+			Attributes struct {
+				PublicID string `json:"public_id"`
+			} `json:"attributes"`
+		} `json:"included"`
+	}
+	if err := getV2(client, auth, "/api/v2/current_user", url.Values{}, &resp); err != nil {
+		return "", err
+	}
+	for _, included := range resp.Included {
+		if included.Type == "orgs" && included.ID == resp.Data.Relationships.Org.Data.ID {
+			return included.Attributes.PublicID, nil
+		}
+	}
+	return "", fmt.Errorf("organization of the current user not found")
+}
+
+// OrganizationSettingsGenerator ...
+type OrganizationSettingsGenerator struct {
+	DatadogService
+}
+
+func (g *OrganizationSettingsGenerator) createResource(publicID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		publicID,
+		publicID,
+		"datadog_organization_settings",
+		"datadog",
+		OrganizationSettingsAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// the settings of the organization are a single TerraformResource.
+// Need Organization Public ID as ID for terraform resource
+func (g *OrganizationSettingsGenerator) InitResources() error {
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	var resp struct {
+		Orgs []organization `json:"orgs"`
+	}
+	if err := getV1(datadogClientV1, authV1, "/api/v1/org", url.Values{}, &resp); err != nil {
+		return err
+	}
+	if len(resp.Orgs) == 0 {
+		return nil
+	}
+
+	publicID := resp.Orgs[0].PublicID
+	// A parent organization lists its child organizations too, keep the one
+	// the keys belong to
+	if len(resp.Orgs) > 1 {
+		datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+		authV2 := g.Args["authV2"].(context.Context)
+
+		currentPublicID, err := currentOrgPublicID(datadogClientV2, authV2)
+		if err != nil {
+			return err
+		}
+		publicID = currentPublicID
+	}
+	g.Resources = []terraformutils.Resource{g.createResource(publicID)}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOrganizationSettingsMultiOrg(t *testing.T) {
+	clientV1, authV1 := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/org" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"orgs": [
+			{"public_id": "parent1", "name": "Parent", "settings": {"saml": {"enabled": true}}},
+			{"public_id": "child2", "name": "Child", "settings": {"saml": {"enabled": false}}}
+		]}`))
+	}))
+	clientV2, authV2 := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/current_user" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {"id": "user-1", "type": "users", "relationships": {"org": {"data": {"id": "uuid-child", "type": "orgs"}}}},
+			"included": [
+				{"id": "uuid-child", "type": "orgs", "attributes": {"public_id": "child2", "name": "Child"}},
+				{"id": "role-1", "type": "roles", "attributes": {"name": "Admin"}}
+			]
+		}`))
+	}))
+
+	g := &OrganizationSettingsGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          authV1,
+		"datadogClientV1": clientV1,
+		"authV2":          authV2,
+		"datadogClientV2": clientV2,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 {
+		t.Fatalf("expected a single organization settings, got %v", g.Resources)
+	}
+	r := g.Resources[0]
+	if r.InstanceState.ID != "child2" || r.ResourceName != "tfer--child2" || r.InstanceInfo.Type != "datadog_organization_settings" {
+		t.Errorf("expected the settings of the organization of the keys, got %s %s %s", r.InstanceInfo.Type, r.ResourceName, r.InstanceState.ID)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// PowerpackAllowEmptyValues ...
+	PowerpackAllowEmptyValues = []string{}
+)
+
+type powerpack struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+}
+
+type powerpacksResponse struct {
+	Data []powerpack `json:"data"`
+}
+
+// listPowerpacks page through the V2 powerpacks API
+func listPowerpacks(client *datadogV2.APIClient, auth context.Context) ([]powerpack, error) {
+	var powerpacks []powerpack
+	limit := 1000
+	for offset := 0; ; offset += limit {
+		var resp powerpacksResponse
+		err := getV2(client, auth, "/api/v2/powerpacks", url.Values{
+			"page[limit]":  []string{strconv.Itoa(limit)},
+			"page[offset]": []string{strconv.Itoa(offset)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		powerpacks = append(powerpacks, resp.Data...)
+		if len(resp.Data) < limit {
+			return powerpacks, nil
+		}
+	}
+}
+
+// PowerpackGenerator ...
+type PowerpackGenerator struct {
+	DatadogService
+}
+
+func (g *PowerpackGenerator) createResources(powerpacks []powerpack) []terraformutils.Resource {
+	names := map[string]int{}
+	for _, powerpack := range powerpacks {
+		names[powerpack.Attributes.Name]++
+	}
+	resources := []terraformutils.Resource{}
+	for _, powerpack := range powerpacks {
+		// Powerpack names aren't unique, the duplicated ones get their id
+		name := powerpack.Attributes.Name
+		if name == "" || names[name] > 1 {
+			name += "_" + powerpack.ID
+		}
+		resources = append(resources, g.createResource(powerpack.ID, name))
+	}
+
+	return resources
+}
+
+func (g *PowerpackGenerator) createResource(powerpackID, name string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		powerpackID,
+		name,
+		"datadog_powerpack",
+		"datadog",
+		PowerpackAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each powerpack create 1 TerraformResource.
+// Need Powerpack ID as ID for terraform resource
+func (g *PowerpackGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("powerpack") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value, "powerpack_"+value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	powerpacks, err := listPowerpacks(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(powerpacks)
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestPowerpackNamesAndDashboardConnection(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/powerpacks" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "pp-1", "type": "powerpack", "attributes": {"name": "Service health"}},
+			{"id": "pp-2", "type": "powerpack", "attributes": {"name": "Host"}},
+			{"id": "pp-3", "type": "powerpack", "attributes": {"name": "Host"}}
+		]}`))
+	}))
+
+	g := &PowerpackGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []struct{ id, name string }{
+		{"pp-1", terraformutils.TfSanitize("Service health")},
+		{"pp-2", terraformutils.TfSanitize("Host_pp-2")},
+		{"pp-3", terraformutils.TfSanitize("Host_pp-3")},
+	} {
+		if r := g.Resources[i]; r.InstanceState.ID != expected.id || r.ResourceName != expected.name {
+			t.Errorf("expected powerpack %s named %s, got %s %s", expected.id, expected.name, r.InstanceState.ID, r.ResourceName)
+		}
+	}
+
+	// the powerpack widgets of dashboards, grouped or not, reference the powerpack
+	powerpack := g.Resources[0]
+	powerpack.InstanceState.Attributes = map[string]string{"id": "pp-1"}
+	dashboard := terraformutils.NewSimpleResource("abc", "dashboard_abc", "datadog_dashboard", "datadog", DashboardAllowEmptyValues)
+	dashboard.Item = map[string]interface{}{"widget": []interface{}{
+		map[string]interface{}{"powerpack_definition": []interface{}{map[string]interface{}{"powerpack_id": "pp-1"}}},
+		map[string]interface{}{"group_definition": []interface{}{map[string]interface{}{"widget": []interface{}{
+			map[string]interface{}{"powerpack_definition": []interface{}{map[string]interface{}{"powerpack_id": "pp-1"}}},
+		}}}},
+	}}
+	terraformutils.ConnectServices(map[string][]terraformutils.Resource{
+		"dashboard": {dashboard},
+		"powerpack": {powerpack},
+	}, true, DatadogProvider{}.GetResourceConnections())
+	for _, path := range []string{
+		"widget.powerpack_definition.powerpack_id",
+		"widget.group_definition.widget.powerpack_definition.powerpack_id",
+	} {
+		powerpackIDs := terraformutils.WalkAndGet(path, dashboard.Item)
+		if len(powerpackIDs) != 1 || powerpackIDs[0] == "pp-1" {
+			t.Errorf("expected the %s widget to reference the powerpack, got %v", path, powerpackIDs)
+		}
+	}
+}
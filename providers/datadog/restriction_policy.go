@@ -0,0 +1,155 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// RestrictionPolicyAllowEmptyValues ...
+	RestrictionPolicyAllowEmptyValues = []string{}
+)
+
+// restrictionPolicyPrefixes map the resource types which can be restricted to
+// the type prefix of their restriction policy resource id
+var restrictionPolicyPrefixes = map[string]string{
+	"datadog_dashboard":                   "dashboard",
+	"datadog_dashboard_json":              "dashboard",
+	"datadog_monitor":                     "monitor",
+	"datadog_powerpack":                   "powerpack",
+	"datadog_security_monitoring_rule":    "security-rule",
+	"datadog_service_level_objective":     "slo",
+	"datadog_synthetics_global_variable":  "synthetics-global-variable",
+	"datadog_synthetics_private_location": "synthetics-private-location",
+	"datadog_synthetics_test":             "synthetics-test",
+}
+
+// restrictionPolicyResourceID return the resource id of the restriction policy
+// of r, or "" if r can't be restricted
+func restrictionPolicyResourceID(r terraformutils.Resource) string {
+	prefix, ok := restrictionPolicyPrefixes[r.InstanceInfo.Type]
+	if !ok {
+		return ""
+	}
+	return prefix + ":" + r.InstanceState.ID
+}
+
+type restrictionPolicyResponse struct {
+	Data struct {
+		Attributes struct {
+			Bindings []interface{} `json:"bindings"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// RestrictionPolicyGenerator ...
+type RestrictionPolicyGenerator struct {
+	DatadogService
+}
+
+func (g *RestrictionPolicyGenerator) createResource(resourceID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		resourceID,
+		fmt.Sprintf("restriction_policy_%s", strings.ReplaceAll(resourceID, ":", "_")),
+		"datadog_restriction_policy",
+		"datadog",
+		RestrictionPolicyAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each resource discovered by the services imported before with an
+// explicit policy create 1 TerraformResource.
+// Need the restricted Resource ID as ID for terraform resource
+func (g *RestrictionPolicyGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("restriction_policy") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	targets, _ := g.Args["restriction-targets"].(map[string]bool)
+	resourceIDs := make([]string, 0, len(targets))
+	for resourceID := range targets {
+		resourceIDs = append(resourceIDs, resourceID)
+	}
+	sort.Strings(resourceIDs)
+
+	for _, resourceID := range resourceIDs {
+		var resp restrictionPolicyResponse
+		err := getV2(datadogClientV2, authV2, "/api/v2/restriction_policy/"+url.PathEscape(resourceID), url.Values{}, &resp)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// Resources without explicit policy have no bindings
+		if len(resp.Data.Attributes.Bindings) == 0 {
+			continue
+		}
+		resources = append(resources, g.createResource(resourceID))
+	}
+	g.Resources = resources
+	return nil
+}
+
+// linkRestrictionPolicies make the restriction policies reference the
+// resource they restrict when exported with it, the resource id is prefixed
+// by its type so it can't be a plain connection
+func linkRestrictionPolicies(importedResource map[string][]terraformutils.Resource, isServicePath bool) {
+	type target struct {
+		service  string
+		resource terraformutils.Resource
+	}
+	targets := map[string]target{}
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if resourceID := restrictionPolicyResourceID(r); resourceID != "" {
+				targets[resourceID] = target{service, r}
+			}
+		}
+	}
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type != "datadog_restriction_policy" {
+				continue
+			}
+			if t, ok := targets[r.InstanceState.ID]; ok {
+				r.Item["resource_id"] = restrictionPolicyPrefixes[t.resource.InstanceInfo.Type] + ":" + connectedID(service, t.service, t.resource, isServicePath)
+			}
+		}
+	}
+}
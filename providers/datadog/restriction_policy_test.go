@@ -0,0 +1,87 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestRestrictionPolicyDiscoveredResources(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/restriction_policy/dashboard:abc-def-ghi":
+			_, _ = w.Write([]byte(`{"data": {"id": "dashboard:abc-def-ghi", "type": "restriction_policy", "attributes": {"bindings": [
+				{"relation": "editor", "principals": ["role:00000000-0000-1111-0000-000000000000"]}
+			]}}}`))
+		case "/api/v2/restriction_policy/slo:123":
+			_, _ = w.Write([]byte(`{"data": {"id": "slo:123", "type": "restriction_policy", "attributes": {"bindings": []}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": ["Not found"]}`))
+		}
+	}))
+
+	// the services imported before record the resources they discovered
+	targets := map[string]bool{}
+	dashboards := &DashboardGenerator{}
+	dashboards.SetArgs(map[string]interface{}{"restriction-targets": targets})
+	dashboard := terraformutils.NewSimpleResource("abc-def-ghi", "dashboard_abc-def-ghi", "datadog_dashboard", "datadog", DashboardAllowEmptyValues)
+	dashboard.Item = map[string]interface{}{"title": "shop"}
+	dashboards.Resources = []terraformutils.Resource{dashboard}
+	if err := dashboards.DatadogService.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+	targets["slo:123"] = true
+	targets["monitor:456"] = true
+
+	g := &RestrictionPolicyGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":              auth,
+		"datadogClientV2":     client,
+		"restriction-targets": targets,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "dashboard:abc-def-ghi" {
+		t.Fatalf("expected only the dashboard explicit policy, got %v", g.Resources)
+	}
+
+	policy := g.Resources[0]
+	policy.Item = map[string]interface{}{"resource_id": "dashboard:abc-def-ghi"}
+	importedResource := map[string][]terraformutils.Resource{
+		"dashboard":          {dashboard},
+		"restriction_policy": {policy},
+	}
+	linkRestrictionPolicies(importedResource, true)
+	if policy.Item["resource_id"] != "dashboard:${data.terraform_remote_state.dashboard.outputs.datadog_dashboard_"+dashboard.ResourceName+"_id}" {
+		t.Errorf("expected the policy to read the dashboard from its remote state, got %v", policy.Item["resource_id"])
+	}
+	policy.Item["resource_id"] = "dashboard:abc-def-ghi"
+	linkRestrictionPolicies(importedResource, false)
+	if policy.Item["resource_id"] != "dashboard:${datadog_dashboard."+dashboard.ResourceName+".id}" {
+		t.Errorf("expected the policy to reference the dashboard, got %v", policy.Item["resource_id"])
+	}
+
+	services := (&DatadogProvider{}).OrderServices([]string{"restriction_policy", "dashboard", "monitor"})
+	if !reflect.DeepEqual(services, []string{"dashboard", "monitor", "restriction_policy"}) {
+		t.Errorf("expected restriction_policy to be imported last, got %v", services)
+	}
+}
@@ -17,6 +17,8 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
 
@@ -31,6 +33,7 @@ var (
 // RoleGenerator ...
 type RoleGenerator struct {
 	DatadogService
+	defaultPermissions map[string]bool
 }
 
 func (g *RoleGenerator) createResources(roles []datadogV2.Role) []terraformutils.Resource {
@@ -66,6 +69,80 @@ func (g *RoleGenerator) InitResources() error {
 	if err != nil {
 		return err
 	}
+	permissions, _, err := datadogClientV1.RolesApi.ListPermissions(authV1).Execute()
+	if err != nil {
+		return err
+	}
+	g.defaultPermissions = unrestrictedPermissions(permissions.GetData())
 	g.Resources = g.createResources(roles.GetData())
 	return nil
 }
+
+// unrestrictedPermissions return the ids of the permissions which are not
+// restricted: every role is granted them by default
+func unrestrictedPermissions(permissions []datadogV2.Permission) map[string]bool {
+	ids := map[string]bool{}
+	for _, permission := range permissions {
+		attributes := permission.GetAttributes()
+		if !attributes.GetRestricted() {
+			ids[permission.GetId()] = true
+		}
+	}
+	return ids
+}
+
+// PostConvertHook keep only the permissions explicitly granted to the roles.
+// The permissions every role inherits by default are read back with the
+// granted ones, declaring them would drift as Datadog changes the defaults,
+// unless the role opts out of the default permissions.
+func (g *RoleGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		if r.InstanceState.Attributes["default_permissions_opt_out"] == "true" {
+			continue
+		}
+		filterStatePermissions(r.InstanceState.Attributes, g.defaultPermissions)
+		permissions, ok := r.Item["permission"].([]interface{})
+		if !ok {
+			continue
+		}
+		granted := []interface{}{}
+		for _, p := range permissions {
+			if permission, ok := p.(map[string]interface{}); ok && g.defaultPermissions[fmt.Sprint(permission["id"])] {
+				continue
+			}
+			granted = append(granted, p)
+		}
+		if len(granted) == 0 {
+			delete(g.Resources[i].Item, "permission")
+			continue
+		}
+		g.Resources[i].Item["permission"] = granted
+	}
+	return g.DatadogService.PostConvertHook()
+}
+
+// filterStatePermissions remove from the flatmapped state of a role the
+// permissions in defaults, so the state matches the generated configuration
+func filterStatePermissions(attributes map[string]string, defaults map[string]bool) {
+	if _, ok := attributes["permission.#"]; !ok {
+		return
+	}
+	var inherited []string
+	for key, value := range attributes {
+		if strings.HasPrefix(key, "permission.") && strings.HasSuffix(key, ".id") && defaults[value] {
+			inherited = append(inherited, strings.TrimSuffix(key, "id"))
+		}
+	}
+	for _, prefix := range inherited {
+		for key := range attributes {
+			if strings.HasPrefix(key, prefix) {
+				delete(attributes, key)
+			}
+		}
+	}
+	count, err := strconv.Atoi(attributes["permission.#"])
+	if err != nil {
+		return
+	}
+	attributes["permission.#"] = strconv.Itoa(count - len(inherited))
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRoleInheritedPermissions(t *testing.T) {
+	newPermission := func(id string, restricted bool) datadogV2.Permission {
+		attributes := datadogV2.PermissionAttributes{}
+		attributes.SetRestricted(restricted)
+		permission := datadogV2.Permission{}
+		permission.SetId(id)
+		permission.SetAttributes(attributes)
+		return permission
+	}
+
+	g := &RoleGenerator{}
+	g.defaultPermissions = unrestrictedPermissions([]datadogV2.Permission{
+		newPermission("logs-read-data", false),
+		newPermission("monitors-write", true),
+	})
+	role := datadogV2.Role{}
+	role.SetId("role-uuid")
+	g.Resources = g.createResources([]datadogV2.Role{role})
+	g.Resources[0].Item = map[string]interface{}{
+		"name": "monitor editors",
+		"permission": []interface{}{
+			map[string]interface{}{"id": "logs-read-data"},
+			map[string]interface{}{"id": "monitors-write"},
+		},
+	}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{map[string]interface{}{"id": "monitors-write"}}
+	if permissions := g.Resources[0].Item["permission"]; !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected only the granted permissions %v, got %v", expected, permissions)
+	}
+}
+
+func TestRoleInheritedPermissionsState(t *testing.T) {
+	g := &RoleGenerator{defaultPermissions: map[string]bool{"logs-read-data": true}}
+	role := datadogV2.Role{}
+	role.SetId("role-uuid")
+	g.Resources = g.createResources([]datadogV2.Role{role})
+	// as refreshed by the provider, permission is a set
+	g.Resources[0].InstanceState.Attributes = map[string]string{
+		"id":                      "role-uuid",
+		"name":                    "monitor editors",
+		"permission.#":            "2",
+		"permission.1234567.id":   "logs-read-data",
+		"permission.1234567.name": "logs_read_data",
+		"permission.7654321.id":   "monitors-write",
+		"permission.7654321.name": "monitors_write",
+	}
+	parseTestState(t, &g.Resources[0], cty.Object(map[string]cty.Type{
+		"name": cty.String,
+		"permission": cty.Set(cty.Object(map[string]cty.Type{
+			"id":   cty.String,
+			"name": cty.String,
+		})),
+	}))
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{map[string]interface{}{"id": "monitors-write"}}
+	if permissions := g.Resources[0].Item["permission"]; !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("expected only the granted permissions %v, got %v", expected, permissions)
+	}
+	expectedState := map[string]string{
+		"id":                      "role-uuid",
+		"name":                    "monitor editors",
+		"permission.#":            "1",
+		"permission.7654321.id":   "monitors-write",
+		"permission.7654321.name": "monitors_write",
+	}
+	if state := g.Resources[0].InstanceState.Attributes; !reflect.DeepEqual(state, expectedState) {
+		t.Errorf("expected the state %v, got %v", expectedState, state)
+	}
+}
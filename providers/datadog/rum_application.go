@@ -0,0 +1,95 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// RUMApplicationAllowEmptyValues ...
+	RUMApplicationAllowEmptyValues = []string{}
+)
+
+type rumApplication struct {
+	ID string `json:"id"`
+}
+
+type rumApplicationsResponse struct {
+	Data []rumApplication `json:"data"`
+}
+
+// RUMApplicationGenerator ...
+type RUMApplicationGenerator struct {
+	DatadogService
+}
+
+func (g *RUMApplicationGenerator) createResources(rumApplications []rumApplication) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, rumApplication := range rumApplications {
+		resources = append(resources, g.createResource(rumApplication.ID))
+	}
+
+	return resources
+}
+
+func (g *RUMApplicationGenerator) createResource(rumApplicationID string) terraformutils.Resource {
+	resource := terraformutils.NewSimpleResource(
+		rumApplicationID,
+		fmt.Sprintf("rum_application_%s", rumApplicationID),
+		"datadog_rum_application",
+		"datadog",
+		RUMApplicationAllowEmptyValues,
+	)
+	// The client token and application id are generated by Datadog, they are
+	// kept in the state but never written to the configuration
+	resource.IgnoreKeys = append(resource.IgnoreKeys, "^client_token$", "^application_id$")
+	return resource
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each RUM application create 1 TerraformResource.
+// Need RUM Application ID as ID for terraform resource
+func (g *RUMApplicationGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("rum_application") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp rumApplicationsResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/rum/applications", url.Values{}, &resp); err != nil {
+		return err
+	}
+	g.Resources = g.createResources(resp.Data)
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRUMApplicationGenerator(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/rum/applications" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [{"id": "abc-123", "type": "rum_application", "attributes": {"application_id": "abc-123", "name": "shop", "type": "browser"}}]}`))
+	}))
+
+	g := &RUMApplicationGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 1 || g.Resources[0].InstanceState.ID != "abc-123" {
+		t.Fatalf("unexpected RUM applications %v", g.Resources)
+	}
+
+	resource := g.Resources[0]
+	resource.InstanceState.Attributes = map[string]string{
+		"id":             "abc-123",
+		"name":           "shop",
+		"type":           "browser",
+		"client_token":   "pub0123456789",
+		"application_id": "abc-123",
+	}
+	impliedType := cty.Object(map[string]cty.Type{
+		"name":           cty.String,
+		"type":           cty.String,
+		"client_token":   cty.String,
+		"application_id": cty.String,
+	})
+	parseTestState(t, &resource, impliedType)
+
+	if resource.Item["name"] != "shop" || resource.Item["type"] != "browser" {
+		t.Errorf("expected name and type to be mapped, got %v", resource.Item)
+	}
+	for _, attribute := range []string{"client_token", "application_id"} {
+		if _, ok := resource.Item[attribute]; ok {
+			t.Errorf("expected %s to be left out of the configuration, got %v", attribute, resource.Item)
+		}
+	}
+	if resource.InstanceState.Attributes["client_token"] != "pub0123456789" {
+		t.Errorf("expected client_token to be kept in the state")
+	}
+}
@@ -36,7 +36,7 @@ type SecurityMonitoringDefaultRuleGenerator struct {
 func (g *SecurityMonitoringDefaultRuleGenerator) createResources(rulesResponse []datadogV2.SecurityMonitoringRuleResponse) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, rule := range rulesResponse {
-		if rule.GetIsDefault() {
+		if rule.GetIsDefault() && isDefaultRuleOverridden(rule) {
 			resourceName := rule.GetId()
 			resources = append(resources, g.createResource(resourceName))
 		}
@@ -56,7 +56,7 @@ func (g *SecurityMonitoringDefaultRuleGenerator) createResource(ruleID string) t
 }
 
 // InitResources Generate TerraformResources from Datadog API,
-// from each SecurityMonitoringDefaultRule create 1 TerraformResource.
+// from each SecurityMonitoringDefaultRule overridden create 1 TerraformResource.
 // Need SecurityMonitoringDefaultRule ID as ID for terraform resource
 func (g *SecurityMonitoringDefaultRuleGenerator) InitResources() error {
 	var securityMonitoringRuleResponses []datadogV2.SecurityMonitoringRuleResponse
@@ -82,3 +82,18 @@ func (g *SecurityMonitoringDefaultRuleGenerator) InitResources() error {
 	g.Resources = g.createResources(securityMonitoringRuleResponses)
 	return nil
 }
+
+// isDefaultRuleOverridden return true if the default rule differs from the one
+// shipped by Datadog: default rules are shipped enabled, without filters and
+// without notifications, rules left at their defaults are not worth managing.
+func isDefaultRuleOverridden(rule datadogV2.SecurityMonitoringRuleResponse) bool {
+	if !rule.GetIsEnabled() || len(rule.GetFilters()) > 0 {
+		return true
+	}
+	for _, ruleCase := range rule.GetCases() {
+		if len(ruleCase.GetNotifications()) > 0 {
+			return true
+		}
+	}
+	return false
+}
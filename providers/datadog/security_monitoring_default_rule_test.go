@@ -0,0 +1,45 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"testing"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+)
+
+func TestSecurityMonitoringDefaultRuleOverridesOnly(t *testing.T) {
+	newRule := func(id string, enabled bool) datadogV2.SecurityMonitoringRuleResponse {
+		rule := datadogV2.SecurityMonitoringRuleResponse{}
+		rule.SetId(id)
+		rule.SetIsDefault(true)
+		rule.SetIsEnabled(enabled)
+		rule.SetCases([]datadogV2.SecurityMonitoringRuleCase{{}})
+		return rule
+	}
+	custom := newRule("custom", false)
+	custom.SetIsDefault(false)
+
+	g := &SecurityMonitoringDefaultRuleGenerator{}
+	resources := g.createResources([]datadogV2.SecurityMonitoringRuleResponse{
+		newRule("default-enabled", true),
+		newRule("default-disabled", false),
+		custom,
+	})
+
+	if len(resources) != 1 || resources[0].InstanceState.ID != "default-disabled" {
+		t.Errorf("expected only the disabled default rule, got %v", resources)
+	}
+}
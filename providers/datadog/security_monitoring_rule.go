@@ -16,7 +16,9 @@ package datadog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 
 	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
@@ -26,27 +28,101 @@ import (
 
 var (
 	// SecurityMonitoringRuleAllowEmptyValues ...
-	SecurityMonitoringRuleAllowEmptyValues = []string{"tags."}
+	SecurityMonitoringRuleAllowEmptyValues = []string{"tags.", "reference_tables."}
 )
 
+// securityMonitoringRuleMethodOptions is the options of a rule specific to
+// its detection method, the client version doesn't know about them
+type securityMonitoringRuleMethodOptions struct {
+	ID      string `json:"id"`
+	Options struct {
+		DetectionMethod string `json:"detectionMethod"`
+		NewValueOptions *struct {
+			ForgetAfter      *int64 `json:"forgetAfter"`
+			LearningDuration *int64 `json:"learningDuration"`
+		} `json:"newValueOptions"`
+		ImpossibleTravelOptions *struct {
+			BaselineUserLocations *bool `json:"baselineUserLocations"`
+		} `json:"impossibleTravelOptions"`
+	} `json:"options"`
+}
+
+type securityMonitoringRulesResponse struct {
+	Data []json.RawMessage `json:"data"`
+	Meta struct {
+		Page struct {
+			TotalCount int64 `json:"total_count"`
+		} `json:"page"`
+	} `json:"meta"`
+}
+
+// listSecurityMonitoringRules page through the security monitoring rules,
+// decoding each rule with the client model and its detection method options
+func listSecurityMonitoringRules(client *datadogV2.APIClient, auth context.Context) ([]datadogV2.SecurityMonitoringRuleResponse, map[string]securityMonitoringRuleMethodOptions, error) {
+	var rules []datadogV2.SecurityMonitoringRuleResponse
+	methodOptions := map[string]securityMonitoringRuleMethodOptions{}
+
+	pageSize := int64(1000)
+	pageNumber := int64(0)
+	remaining := int64(1)
+
+	for remaining > int64(0) {
+		var resp securityMonitoringRulesResponse
+		err := getV2(client, auth, "/api/v2/security_monitoring/rules", url.Values{
+			"page[size]":   []string{strconv.FormatInt(pageSize, 10)},
+			"page[number]": []string{strconv.FormatInt(pageNumber, 10)},
+		}, &resp)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, data := range resp.Data {
+			var rule datadogV2.SecurityMonitoringRuleResponse
+			if err := json.Unmarshal(data, &rule); err != nil {
+				return nil, nil, err
+			}
+			var options securityMonitoringRuleMethodOptions
+			if err := json.Unmarshal(data, &options); err != nil {
+				return nil, nil, err
+			}
+			rules = append(rules, rule)
+			methodOptions[options.ID] = options
+		}
+
+		remaining = resp.Meta.Page.TotalCount - pageSize*(pageNumber+1)
+		pageNumber++
+	}
+	return rules, methodOptions, nil
+}
+
 // SecurityMonitoringRuleGenerator ...
 type SecurityMonitoringRuleGenerator struct {
 	DatadogService
+	queries       map[string][]datadogV2.SecurityMonitoringRuleQuery
+	methodOptions map[string]securityMonitoringRuleMethodOptions
 }
 
 func (g *SecurityMonitoringRuleGenerator) createResources(rulesResponse []datadogV2.SecurityMonitoringRuleResponse) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
+	g.queries = map[string][]datadogV2.SecurityMonitoringRuleQuery{}
 	for _, rule := range rulesResponse {
 		if !rule.GetIsDefault() {
 			resourceName := rule.GetId()
-			resources = append(resources, g.createResource(resourceName, rule.GetIsEnabled()))
+			g.queries[resourceName] = rule.GetQueries()
+			resources = append(resources, g.createResource(resourceName, rule.GetIsEnabled(), rule.GetTags()))
 		}
 	}
 
 	return resources
 }
 
-func (g *SecurityMonitoringRuleGenerator) createResource(ruleID string, ruleEnabled bool) terraformutils.Resource {
+func (g *SecurityMonitoringRuleGenerator) createResource(ruleID string, ruleEnabled bool, ruleTags []string) terraformutils.Resource {
+	additionalFields := map[string]interface{}{}
+	// tags are stored as a set in state, keep the order returned by the API
+	// so MITRE tactic/technique tags stay next to each other
+	if len(ruleTags) > 0 {
+		additionalFields["tags"] = ruleTags
+	}
+
 	return terraformutils.NewResource(
 		ruleID,
 		fmt.Sprintf("security_monitoring_rule_%s", ruleID),
@@ -56,7 +132,7 @@ func (g *SecurityMonitoringRuleGenerator) createResource(ruleID string, ruleEnab
 			"enabled": strconv.FormatBool(ruleEnabled),
 		},
 		SecurityMonitoringRuleAllowEmptyValues,
-		map[string]interface{}{},
+		additionalFields,
 	)
 }
 
@@ -64,26 +140,91 @@ func (g *SecurityMonitoringRuleGenerator) createResource(ruleID string, ruleEnab
 // from each SecurityMonitoringRule create 1 TerraformResource.
 // Need SecurityMonitoringRule ID as ID for terraform resource
 func (g *SecurityMonitoringRuleGenerator) InitResources() error {
-	var securityMonitoringRuleResponses []datadogV2.SecurityMonitoringRuleResponse
-
 	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
 	authV2 := g.Args["authV2"].(context.Context)
 
-	pageSize := int64(1000)
-	pageNumber := int64(0)
-	remaining := int64(1)
+	securityMonitoringRuleResponses, methodOptions, err := listSecurityMonitoringRules(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.methodOptions = methodOptions
 
-	for remaining > int64(0) {
-		resp, _, err := datadogClientV2.SecurityMonitoringApi.ListSecurityMonitoringRules(authV2).PageSize(pageSize).PageNumber(pageNumber).Execute()
-		if err != nil {
-			return err
+	g.Resources = g.createResources(securityMonitoringRuleResponses)
+	return nil
+}
+
+// PostConvertHook restore the query group_by_fields, distinct_fields and
+// aggregation returned by the API when they are missing from the state,
+// without them the rule would be recreated grouping on nothing. The options
+// of the new_value and impossible_travel detection methods are restored too.
+func (g *SecurityMonitoringRuleGenerator) PostConvertHook() error {
+	for _, r := range g.Resources {
+		if methodOptions, ok := g.methodOptions[r.InstanceState.ID]; ok {
+			restoreSecurityMonitoringRuleMethodOptions(r.Item, methodOptions)
+		}
+		queries, ok := r.Item["query"].([]interface{})
+		if !ok {
+			continue
 		}
-		securityMonitoringRuleResponses = append(securityMonitoringRuleResponses, resp.GetData()...)
+		for j, ruleQuery := range g.queries[r.InstanceState.ID] {
+			if j >= len(queries) {
+				break
+			}
+			query, ok := queries[j].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, ok := query["group_by_fields"]; !ok && len(ruleQuery.GetGroupByFields()) > 0 {
+				query["group_by_fields"] = ruleQuery.GetGroupByFields()
+			}
+			if _, ok := query["distinct_fields"]; !ok && len(ruleQuery.GetDistinctFields()) > 0 {
+				query["distinct_fields"] = ruleQuery.GetDistinctFields()
+			}
+			if _, ok := query["aggregation"]; !ok && ruleQuery.HasAggregation() {
+				query["aggregation"] = string(ruleQuery.GetAggregation())
+			}
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
 
-		remaining = resp.Meta.Page.GetTotalCount() - pageSize*(pageNumber+1)
-		pageNumber++
+// restoreSecurityMonitoringRuleMethodOptions set the detection method and its
+// options missing from the options block of item
+func restoreSecurityMonitoringRuleMethodOptions(item map[string]interface{}, methodOptions securityMonitoringRuleMethodOptions) {
+	if methodOptions.Options.DetectionMethod == "" {
+		return
+	}
+	var options map[string]interface{}
+	if blocks, ok := item["options"].([]interface{}); ok && len(blocks) == 1 {
+		options, _ = blocks[0].(map[string]interface{})
+	}
+	if options == nil {
+		options = map[string]interface{}{}
+		item["options"] = []interface{}{options}
+	}
+	if _, ok := options["detection_method"]; !ok {
+		options["detection_method"] = methodOptions.Options.DetectionMethod
 	}
 
-	g.Resources = g.createResources(securityMonitoringRuleResponses)
-	return nil
+	if newValue := methodOptions.Options.NewValueOptions; newValue != nil {
+		if _, ok := options["new_value_options"]; !ok {
+			newValueOptions := map[string]interface{}{}
+			if newValue.ForgetAfter != nil {
+				newValueOptions["forget_after"] = *newValue.ForgetAfter
+			}
+			if newValue.LearningDuration != nil {
+				newValueOptions["learning_duration"] = *newValue.LearningDuration
+			}
+			options["new_value_options"] = []interface{}{newValueOptions}
+		}
+	}
+	if impossibleTravel := methodOptions.Options.ImpossibleTravelOptions; impossibleTravel != nil {
+		if _, ok := options["impossible_travel_options"]; !ok {
+			impossibleTravelOptions := map[string]interface{}{}
+			if impossibleTravel.BaselineUserLocations != nil {
+				impossibleTravelOptions["baseline_user_locations"] = *impossibleTravel.BaselineUserLocations
+			}
+			options["impossible_travel_options"] = []interface{}{impossibleTravelOptions}
+		}
+	}
 }
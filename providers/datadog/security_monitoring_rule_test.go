@@ -0,0 +1,198 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSecurityMonitoringRuleGroupByAndDistinctFields(t *testing.T) {
+	query := datadogV2.SecurityMonitoringRuleQuery{}
+	query.SetQuery("source:cloudtrail @evt.name:ConsoleLogin")
+	query.SetAggregation(datadogV2.SECURITYMONITORINGRULEQUERYAGGREGATION_CARDINALITY)
+	query.SetGroupByFields([]string{"@usr.id", "@network.client.ip"})
+	query.SetDistinctFields([]string{"@network.client.geoip.country.name"})
+	rule := datadogV2.SecurityMonitoringRuleResponse{}
+	rule.SetId("abc-def-ghi")
+	rule.SetIsDefault(false)
+	rule.SetQueries([]datadogV2.SecurityMonitoringRuleQuery{query})
+
+	g := &SecurityMonitoringRuleGenerator{}
+	g.Resources = g.createResources([]datadogV2.SecurityMonitoringRuleResponse{rule})
+	// group_by_fields is read back from the state, distinct_fields and
+	// aggregation are missing from it
+	g.Resources[0].InstanceState.Attributes = map[string]string{
+		"id":                        "abc-def-ghi",
+		"name":                      "Console login from new countries",
+		"has_extended_title":        "true",
+		"query.#":                   "1",
+		"query.0.query":             "source:cloudtrail @evt.name:ConsoleLogin",
+		"query.0.group_by_fields.#": "2",
+		"query.0.group_by_fields.0": "@usr.id",
+		"query.0.group_by_fields.1": "@network.client.ip",
+	}
+	impliedType := cty.Object(map[string]cty.Type{
+		"name":               cty.String,
+		"has_extended_title": cty.Bool,
+		"query": cty.List(cty.Object(map[string]cty.Type{
+			"query":           cty.String,
+			"aggregation":     cty.String,
+			"group_by_fields": cty.List(cty.String),
+			"distinct_fields": cty.List(cty.String),
+		})),
+	})
+	parseTestState(t, &g.Resources[0], impliedType)
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	item := g.Resources[0].Item
+	if item["has_extended_title"] != "true" {
+		t.Errorf("expected has_extended_title to be kept, got %v", item["has_extended_title"])
+	}
+	ruleQuery := item["query"].([]interface{})[0].(map[string]interface{})
+	if groupBy := ruleQuery["group_by_fields"]; !reflect.DeepEqual(groupBy, []interface{}{"@usr.id", "@network.client.ip"}) {
+		t.Errorf("unexpected group_by_fields %v", groupBy)
+	}
+	if distinct := ruleQuery["distinct_fields"]; !reflect.DeepEqual(distinct, []string{"@network.client.geoip.country.name"}) {
+		t.Errorf("unexpected distinct_fields %v", distinct)
+	}
+	if aggregation := ruleQuery["aggregation"]; aggregation != "cardinality" {
+		t.Errorf("unexpected aggregation %v", aggregation)
+	}
+}
+
+func TestSecurityMonitoringRuleDetectionMethodOptions(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/security_monitoring/rules" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "new-value", "isDefault": false, "isEnabled": true, "name": "New country",
+				"options": {"detectionMethod": "new_value", "newValueOptions": {"forgetAfter": 7, "learningDuration": 1}}},
+			{"id": "impossible-travel", "isDefault": false, "isEnabled": true, "name": "Impossible travel",
+				"options": {"detectionMethod": "impossible_travel", "impossibleTravelOptions": {"baselineUserLocations": true}}},
+			{"id": "threshold", "isDefault": false, "isEnabled": true, "name": "Threshold",
+				"options": {"detectionMethod": "threshold"}}
+		], "meta": {"page": {"total_count": 3}}}`))
+	}))
+
+	g := &SecurityMonitoringRuleGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 3 {
+		t.Fatalf("expected 3 rules, got %v", g.Resources)
+	}
+	// the method options are missing from the state
+	for i := range g.Resources {
+		g.Resources[i].Item = map[string]interface{}{
+			"options": []interface{}{map[string]interface{}{"keep_alive": 3600}},
+		}
+	}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []map[string]interface{}{
+		{
+			"keep_alive":        3600,
+			"detection_method":  "new_value",
+			"new_value_options": []interface{}{map[string]interface{}{"forget_after": int64(7), "learning_duration": int64(1)}},
+		},
+		{
+			"keep_alive":                3600,
+			"detection_method":          "impossible_travel",
+			"impossible_travel_options": []interface{}{map[string]interface{}{"baseline_user_locations": true}},
+		},
+		{
+			"keep_alive":       3600,
+			"detection_method": "threshold",
+		},
+	} {
+		options := g.Resources[i].Item["options"].([]interface{})[0]
+		if !reflect.DeepEqual(options, expected) {
+			t.Errorf("unexpected %s options %v", g.Resources[i].InstanceState.ID, options)
+		}
+	}
+}
+
+func TestSecurityMonitoringRuleReferenceTables(t *testing.T) {
+	rule := datadogV2.SecurityMonitoringRuleResponse{}
+	rule.SetId("abc-def-ghi")
+	rule.SetIsDefault(false)
+	rule.SetIsEnabled(true)
+	rule.SetTags([]string{"tactic:TA0001-initial-access", "technique:T1078-valid-accounts", "source:auth"})
+
+	g := &SecurityMonitoringRuleGenerator{}
+	g.Resources = g.createResources([]datadogV2.SecurityMonitoringRuleResponse{rule})
+	// tags are a set in the state, read back in another order
+	g.Resources[0].InstanceState.Attributes = map[string]string{
+		"id":                                 "abc-def-ghi",
+		"name":                               "Suspicious IP login",
+		"enabled":                            "true",
+		"reference_tables.#":                 "1",
+		"reference_tables.0.table_name":      "threat_intel_ips",
+		"reference_tables.0.column_name":     "ip",
+		"reference_tables.0.log_field_path":  "network.client.ip",
+		"reference_tables.0.rule_query_name": "logins",
+		"reference_tables.0.check_presence":  "true",
+		"tags.#":                             "3",
+		"tags.1234":                          "source:auth",
+		"tags.5678":                          "tactic:TA0001-initial-access",
+		"tags.9012":                          "technique:T1078-valid-accounts",
+	}
+	impliedType := cty.Object(map[string]cty.Type{
+		"name":    cty.String,
+		"enabled": cty.Bool,
+		"reference_tables": cty.List(cty.Object(map[string]cty.Type{
+			"table_name":      cty.String,
+			"column_name":     cty.String,
+			"log_field_path":  cty.String,
+			"rule_query_name": cty.String,
+			"check_presence":  cty.Bool,
+		})),
+		"tags": cty.Set(cty.String),
+	})
+	parseTestState(t, &g.Resources[0], impliedType)
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	item := g.Resources[0].Item
+	expectedTables := []interface{}{map[string]interface{}{
+		"table_name":      "threat_intel_ips",
+		"column_name":     "ip",
+		"log_field_path":  "network.client.ip",
+		"rule_query_name": "logins",
+		"check_presence":  "true",
+	}}
+	if tables := item["reference_tables"]; !reflect.DeepEqual(tables, expectedTables) {
+		t.Errorf("expected reference_tables %v, got %v", expectedTables, tables)
+	}
+	if tags := item["tags"]; !reflect.DeepEqual(tags, rule.GetTags()) {
+		t.Errorf("expected the tags in the API order %v, got %v", rule.GetTags(), tags)
+	}
+}
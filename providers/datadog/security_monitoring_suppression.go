@@ -0,0 +1,113 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// SecurityMonitoringSuppressionAllowEmptyValues ...
+	SecurityMonitoringSuppressionAllowEmptyValues = []string{}
+)
+
+type securityMonitoringSuppressionsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// SecurityMonitoringSuppressionGenerator ...
+type SecurityMonitoringSuppressionGenerator struct {
+	DatadogService
+}
+
+func (g *SecurityMonitoringSuppressionGenerator) createResource(suppressionID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		suppressionID,
+		fmt.Sprintf("security_monitoring_suppression_%s", suppressionID),
+		"datadog_security_monitoring_suppression",
+		"datadog",
+		SecurityMonitoringSuppressionAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each security monitoring suppression create 1 TerraformResource,
+// expired suppressions included.
+// Need Suppression ID as ID for terraform resource
+func (g *SecurityMonitoringSuppressionGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("security_monitoring_suppression") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	var resp securityMonitoringSuppressionsResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/security_monitoring/configuration/suppressions", url.Values{}, &resp); err != nil {
+		return err
+	}
+	for _, suppression := range resp.Data {
+		resources = append(resources, g.createResource(suppression.ID))
+	}
+	g.Resources = resources
+	return nil
+}
+
+// linkSecurityMonitoringSuppressions make the suppressions reference the
+// rules exported with them, the rule id is a ruleId: term of the rule_query
+// so it can't be a plain connection
+func linkSecurityMonitoringSuppressions(importedResource map[string][]terraformutils.Resource, isServicePath bool) {
+	for service, resources := range importedResource {
+		var terms []string
+		for ruleService, rules := range importedResource {
+			for _, r := range rules {
+				if r.InstanceInfo.Type == "datadog_security_monitoring_rule" {
+					terms = append(terms, "ruleId:"+r.InstanceState.ID, "ruleId:"+connectedID(service, ruleService, r, isServicePath))
+				}
+			}
+		}
+		if len(terms) == 0 {
+			return
+		}
+		replacer := strings.NewReplacer(terms...)
+		for _, r := range resources {
+			if r.InstanceInfo.Type != "datadog_security_monitoring_suppression" {
+				continue
+			}
+			if ruleQuery, ok := r.Item["rule_query"].(string); ok {
+				r.Item["rule_query"] = replacer.Replace(ruleQuery)
+			}
+		}
+	}
+}
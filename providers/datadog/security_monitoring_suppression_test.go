@@ -0,0 +1,66 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestSecurityMonitoringSuppressions(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/security_monitoring/configuration/suppressions" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "aaa-bbb-ccc", "type": "suppressions", "attributes": {"name": "scanners", "enabled": true, "rule_query": "ruleId:abc-def-ghi", "suppression_query": "@ip:10.0.0.1"}},
+			{"id": "ddd-eee-fff", "type": "suppressions", "attributes": {"name": "expired", "enabled": true, "rule_query": "type:log_detection", "expiration_date": 1577836800000}}
+		]}`))
+	}))
+
+	g := &SecurityMonitoringSuppressionGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 2 {
+		t.Fatalf("expected 2 suppressions with the expired one, got %d", len(g.Resources))
+	}
+
+	suppression := g.Resources[0]
+	suppression.Item = map[string]interface{}{"name": "scanners", "rule_query": "ruleId:abc-def-ghi OR ruleId:unknown"}
+	rule := terraformutils.NewSimpleResource("abc-def-ghi", "security_monitoring_rule_abc-def-ghi", "datadog_security_monitoring_rule", "datadog", SecurityMonitoringRuleAllowEmptyValues)
+	importedResource := map[string][]terraformutils.Resource{
+		"security_monitoring_rule":        {rule},
+		"security_monitoring_suppression": {suppression},
+	}
+	linkSecurityMonitoringSuppressions(importedResource, true)
+	expected := "ruleId:${data.terraform_remote_state.security_monitoring_rule.outputs.datadog_security_monitoring_rule_" + rule.ResourceName + "_id} OR ruleId:unknown"
+	if suppression.Item["rule_query"] != expected {
+		t.Errorf("expected the suppression to read the rule from its remote state, got %v", suppression.Item["rule_query"])
+	}
+	suppression.Item["rule_query"] = "ruleId:abc-def-ghi OR ruleId:unknown"
+	linkSecurityMonitoringSuppressions(importedResource, false)
+	expected = "ruleId:${datadog_security_monitoring_rule." + rule.ResourceName + ".id} OR ruleId:unknown"
+	if suppression.Item["rule_query"] != expected {
+		t.Errorf("expected the suppression to reference the rule, got %v", suppression.Item["rule_query"])
+	}
+}
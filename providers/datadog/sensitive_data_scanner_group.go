@@ -0,0 +1,118 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// SensitiveDataScannerGroupAllowEmptyValues ...
+	SensitiveDataScannerGroupAllowEmptyValues = []string{}
+)
+
+// sensitiveDataScannerIncluded is a group or a rule included in the
+// Sensitive Data Scanner configuration
+type sensitiveDataScannerIncluded struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	Relationships struct {
+		StandardPattern struct {
+			Data *struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"standard_pattern"`
+	} `json:"relationships"`
+}
+
+// listSensitiveDataScanner return the groups and the rules of the Sensitive
+// Data Scanner configuration, in their order
+func listSensitiveDataScanner(client *datadogV2.APIClient, auth context.Context) ([]sensitiveDataScannerIncluded, []sensitiveDataScannerIncluded, error) {
+	var resp struct {
+		Included []sensitiveDataScannerIncluded `json:"included"`
+	}
+	if err := getV2(client, auth, "/api/v2/sensitive-data-scanner/config", url.Values{}, &resp); err != nil {
+		return nil, nil, err
+	}
+	var groups, rules []sensitiveDataScannerIncluded
+	for _, included := range resp.Included {
+		switch included.Type {
+		case "sensitive_data_scanner_group":
+			groups = append(groups, included)
+		case "sensitive_data_scanner_rule":
+			rules = append(rules, included)
+		}
+	}
+	return groups, rules, nil
+}
+
+// SensitiveDataScannerGroupGenerator ...
+type SensitiveDataScannerGroupGenerator struct {
+	DatadogService
+}
+
+func (g *SensitiveDataScannerGroupGenerator) createResources(groups []sensitiveDataScannerIncluded) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, group := range groups {
+		resources = append(resources, g.createResource(group.ID))
+	}
+
+	return resources
+}
+
+func (g *SensitiveDataScannerGroupGenerator) createResource(groupID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		groupID,
+		fmt.Sprintf("sensitive_data_scanner_group_%s", groupID),
+		"datadog_sensitive_data_scanner_group",
+		"datadog",
+		SensitiveDataScannerGroupAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each scanning group create 1 TerraformResource.
+// Need Group ID as ID for terraform resource
+func (g *SensitiveDataScannerGroupGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("sensitive_data_scanner_group") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	groups, _, err := listSensitiveDataScanner(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(groups)
+	return nil
+}
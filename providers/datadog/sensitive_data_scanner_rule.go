@@ -0,0 +1,100 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// SensitiveDataScannerRuleAllowEmptyValues ...
+	SensitiveDataScannerRuleAllowEmptyValues = []string{}
+)
+
+// SensitiveDataScannerRuleGenerator ...
+type SensitiveDataScannerRuleGenerator struct {
+	DatadogService
+	standardPatterns map[string]string
+}
+
+func (g *SensitiveDataScannerRuleGenerator) createResources(rules []sensitiveDataScannerIncluded) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	g.standardPatterns = map[string]string{}
+	for _, rule := range rules {
+		if standardPattern := rule.Relationships.StandardPattern.Data; standardPattern != nil && standardPattern.ID != "" {
+			g.standardPatterns[rule.ID] = standardPattern.ID
+		}
+		resources = append(resources, g.createResource(rule.ID))
+	}
+
+	return resources
+}
+
+func (g *SensitiveDataScannerRuleGenerator) createResource(ruleID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		ruleID,
+		fmt.Sprintf("sensitive_data_scanner_rule_%s", ruleID),
+		"datadog_sensitive_data_scanner_rule",
+		"datadog",
+		SensitiveDataScannerRuleAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each scanning rule create 1 TerraformResource.
+// Need Rule ID as ID for terraform resource
+func (g *SensitiveDataScannerRuleGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("sensitive_data_scanner_rule") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	_, rules, err := listSensitiveDataScanner(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(rules)
+	return nil
+}
+
+// PostConvertHook keep the rules using a standard pattern of the library
+// referencing it by id, their pattern is the one of the library and is left
+// out so it follows the library updates
+func (g *SensitiveDataScannerRuleGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		if standardPatternID, ok := g.standardPatterns[r.InstanceState.ID]; ok {
+			g.Resources[i].Item["standard_pattern_id"] = standardPatternID
+			delete(g.Resources[i].Item, "pattern")
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
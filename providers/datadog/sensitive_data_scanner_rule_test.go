@@ -0,0 +1,77 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+const testSensitiveDataScannerConfig = `{
+	"data": {"id": "config-1", "type": "sensitive_data_scanner_configuration", "relationships": {"groups": {"data": [{"id": "group-1", "type": "sensitive_data_scanner_group"}]}}},
+	"included": [
+		{"id": "group-1", "type": "sensitive_data_scanner_group", "attributes": {"name": "PII", "filter": {"query": "env:prod"}, "product_list": ["logs"]}},
+		{"id": "rule-1", "type": "sensitive_data_scanner_rule", "attributes": {"name": "emails", "pattern": "[a-z]+@example.com"},
+			"relationships": {"group": {"data": {"id": "group-1", "type": "sensitive_data_scanner_group"}}}},
+		{"id": "rule-2", "type": "sensitive_data_scanner_rule", "attributes": {"name": "credit cards", "pattern": "\\d{16}"},
+			"relationships": {"group": {"data": {"id": "group-1", "type": "sensitive_data_scanner_group"}}, "standard_pattern": {"data": {"id": "pattern-cc", "type": "sensitive_data_scanner_standard_pattern"}}}}
+	]
+}`
+
+func TestSensitiveDataScannerGroupsAndRules(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/sensitive-data-scanner/config" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testSensitiveDataScannerConfig))
+	}))
+	args := map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	}
+
+	groups := &SensitiveDataScannerGroupGenerator{}
+	groups.SetArgs(args)
+	if err := groups.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(groups.Resources) != 1 || groups.Resources[0].InstanceState.ID != "group-1" {
+		t.Fatalf("expected the scanning group, got %v", groups.Resources)
+	}
+
+	rules := &SensitiveDataScannerRuleGenerator{}
+	rules.SetArgs(args)
+	if err := rules.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.Resources) != 2 {
+		t.Fatalf("expected 2 scanning rules, got %v", rules.Resources)
+	}
+	for i := range rules.Resources {
+		rules.Resources[i].Item = map[string]interface{}{"group_id": "group-1", "pattern": "normalized"}
+	}
+	if err := rules.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	custom, standard := rules.Resources[0].Item, rules.Resources[1].Item
+	if custom["pattern"] != "normalized" || custom["standard_pattern_id"] != nil {
+		t.Errorf("expected the custom rule to keep its pattern, got %v", custom)
+	}
+	if standard["standard_pattern_id"] != "pattern-cc" || standard["pattern"] != nil {
+		t.Errorf("expected the standard rule to reference its library pattern, got %v", standard)
+	}
+}
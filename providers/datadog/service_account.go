@@ -0,0 +1,139 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// ServiceAccountAllowEmptyValues ...
+	ServiceAccountAllowEmptyValues = []string{}
+)
+
+// serviceAccountUser is a V2 user, the client version doesn't know about
+// the service_account attribute
+type serviceAccountUser struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		ServiceAccount bool `json:"service_account"`
+		Disabled       bool `json:"disabled"`
+	} `json:"attributes"`
+}
+
+type serviceAccountUsersResponse struct {
+	Data []serviceAccountUser `json:"data"`
+}
+
+// listServiceAccounts page through the V2 users API, keeping only the
+// service accounts
+func listServiceAccounts(client *datadogV2.APIClient, auth context.Context) ([]serviceAccountUser, error) {
+	var serviceAccounts []serviceAccountUser
+	pageSize := 1000
+	for pageNumber := 0; ; pageNumber++ {
+		var resp serviceAccountUsersResponse
+		err := getV2(client, auth, "/api/v2/users", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range resp.Data {
+			if user.Attributes.ServiceAccount {
+				serviceAccounts = append(serviceAccounts, user)
+			}
+		}
+		if len(resp.Data) < pageSize {
+			return serviceAccounts, nil
+		}
+	}
+}
+
+// ServiceAccountGenerator ...
+type ServiceAccountGenerator struct {
+	DatadogService
+	disabled map[string]bool
+}
+
+func (g *ServiceAccountGenerator) createResources(serviceAccounts []serviceAccountUser) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	g.disabled = map[string]bool{}
+	for _, serviceAccount := range serviceAccounts {
+		if serviceAccount.Attributes.Disabled {
+			g.disabled[serviceAccount.ID] = true
+		}
+		resources = append(resources, g.createResource(serviceAccount.ID))
+	}
+
+	return resources
+}
+
+func (g *ServiceAccountGenerator) createResource(serviceAccountID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		serviceAccountID,
+		fmt.Sprintf("service_account_%s", serviceAccountID),
+		"datadog_service_account",
+		"datadog",
+		ServiceAccountAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each service account create 1 TerraformResource.
+// Need Service Account ID as ID for terraform resource
+func (g *ServiceAccountGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("service_account") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	serviceAccounts, err := listServiceAccounts(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(serviceAccounts)
+	return nil
+}
+
+// PostConvertHook keep the disabled service accounts disabled, the provider
+// defaults disabled to false and it would otherwise be dropped as empty
+func (g *ServiceAccountGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		if g.disabled[r.InstanceState.ID] {
+			g.Resources[i].Item["disabled"] = true
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServiceAccountFilterAndDisabled(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/users" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"id": "human", "type": "users", "attributes": {"email": "jane@example.com", "service_account": false}},
+			{"id": "ci", "type": "users", "attributes": {"email": "ci@example.com", "service_account": true, "disabled": false}},
+			{"id": "legacy", "type": "users", "attributes": {"email": "legacy@example.com", "service_account": true, "disabled": true}}
+		]}`))
+	}))
+
+	g := &ServiceAccountGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 2 {
+		t.Fatalf("expected 2 service accounts, got %v", g.Resources)
+	}
+	for i := range g.Resources {
+		g.Resources[i].Item = map[string]interface{}{"email": g.Resources[i].InstanceState.ID + "@example.com"}
+	}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []struct {
+		id       string
+		disabled bool
+	}{{"ci", false}, {"legacy", true}} {
+		r := g.Resources[i]
+		if r.InstanceState.ID != expected.id || r.InstanceInfo.Type != "datadog_service_account" {
+			t.Errorf("unexpected service account %s %s", r.InstanceInfo.Type, r.InstanceState.ID)
+		}
+		if disabled, _ := r.Item["disabled"].(bool); disabled != expected.disabled {
+			t.Errorf("expected %s disabled %v, got %v", expected.id, expected.disabled, r.Item["disabled"])
+		}
+	}
+}
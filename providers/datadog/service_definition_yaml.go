@@ -0,0 +1,133 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// ServiceDefinitionAllowEmptyValues ...
+	ServiceDefinitionAllowEmptyValues = []string{}
+)
+
+// serviceDefinition is a service catalog definition, its schema is kept as
+// returned so the v2, v2.1 and v2.2 documents keep their own fields
+type serviceDefinition struct {
+	Attributes struct {
+		Schema json.RawMessage `json:"schema"`
+	} `json:"attributes"`
+}
+
+type serviceDefinitionsResponse struct {
+	Data []serviceDefinition `json:"data"`
+}
+
+// listServiceDefinitions page through the V2 service definitions API
+func listServiceDefinitions(client *datadogV2.APIClient, auth context.Context) ([]serviceDefinition, error) {
+	var definitions []serviceDefinition
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		var resp serviceDefinitionsResponse
+		err := getV2(client, auth, "/api/v2/services/definitions", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return definitions, nil
+		}
+	}
+}
+
+// ServiceDefinitionGenerator ...
+type ServiceDefinitionGenerator struct {
+	DatadogService
+	documents map[string]string
+}
+
+func (g *ServiceDefinitionGenerator) createResources(definitions []serviceDefinition) ([]terraformutils.Resource, error) {
+	resources := []terraformutils.Resource{}
+	g.documents = map[string]string{}
+	for _, definition := range definitions {
+		var schema struct {
+			DDService string `json:"dd-service"`
+		}
+		if err := json.Unmarshal(definition.Attributes.Schema, &schema); err != nil {
+			return nil, err
+		}
+		// JSON is valid YAML, only the whitespaces are compacted
+		var document bytes.Buffer
+		if err := json.Compact(&document, definition.Attributes.Schema); err != nil {
+			return nil, err
+		}
+		g.documents[schema.DDService] = document.String()
+		resources = append(resources, g.createResource(schema.DDService))
+	}
+
+	return resources, nil
+}
+
+func (g *ServiceDefinitionGenerator) createResource(serviceName string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		serviceName,
+		serviceName,
+		"datadog_service_definition_yaml",
+		"datadog",
+		ServiceDefinitionAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each service definition create 1 TerraformResource.
+// Need Service Name (dd-service) as ID for terraform resource
+func (g *ServiceDefinitionGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	definitions, err := listServiceDefinitions(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	resources, err := g.createResources(definitions)
+	if err != nil {
+		return err
+	}
+	g.Resources = resources
+	return nil
+}
+
+// PostConvertHook write the service definition document as returned by the
+// API instead of the one read back by the provider, to keep its schema version
+// and fields as authored
+func (g *ServiceDefinitionGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		if document, ok := g.documents[r.InstanceState.ID]; ok {
+			g.Resources[i].Item["service_definition"] = escapeTemplateSequences(document)
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
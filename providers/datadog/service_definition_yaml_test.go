@@ -0,0 +1,69 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServiceDefinitionSchemaVersions(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/services/definitions" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"type": "service-definition", "attributes": {"schema": {"schema-version": "v2", "dd-service": "shopping-cart", "team": "checkout"}}},
+			{"type": "service-definition", "attributes": {"schema": {
+				"schema-version": "v2.2",
+				"dd-service": "payments",
+				"application": "shop",
+				"ci-pipeline-fingerprints": ["abc"]
+			}}}
+		]}`))
+	}))
+
+	g := &ServiceDefinitionGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 2 {
+		t.Fatalf("expected 2 service definitions, got %v", g.Resources)
+	}
+	for i := range g.Resources {
+		g.Resources[i].Item = map[string]interface{}{"service_definition": "normalized"}
+	}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []struct{ id, name, document string }{
+		{"shopping-cart", "tfer--shopping-002D-cart", `{"schema-version":"v2","dd-service":"shopping-cart","team":"checkout"}`},
+		{"payments", "tfer--payments", `{"schema-version":"v2.2","dd-service":"payments","application":"shop","ci-pipeline-fingerprints":["abc"]}`},
+	} {
+		r := g.Resources[i]
+		if r.InstanceState.ID != expected.id || r.ResourceName != expected.name {
+			t.Errorf("unexpected service definition %s %s", r.InstanceState.ID, r.ResourceName)
+		}
+		if document := r.Item["service_definition"]; document != expected.document {
+			t.Errorf("expected the document %s as authored, got %v", expected.document, document)
+		}
+	}
+}
@@ -38,19 +38,28 @@ func (g *ServiceLevelObjectiveGenerator) createResources(sloList []datadogV1.Ser
 	resources := []terraformutils.Resource{}
 	for _, slo := range sloList {
 		resourceID := slo.GetId()
-		resources = append(resources, g.createResource(resourceID))
+		resources = append(resources, g.createResource(resourceID, slo.GetGroups()))
 	}
 
 	return resources
 }
 
-func (g *ServiceLevelObjectiveGenerator) createResource(sloID string) terraformutils.Resource {
-	return terraformutils.NewSimpleResource(
+func (g *ServiceLevelObjectiveGenerator) createResource(sloID string, groups []string) terraformutils.Resource {
+	additionalFields := map[string]interface{}{}
+	// groups restrict a grouped monitor SLO to a subset of the monitor groups,
+	// keep them in the order returned by the API
+	if len(groups) > 0 {
+		additionalFields["groups"] = groups
+	}
+
+	return terraformutils.NewResource(
 		sloID,
 		fmt.Sprintf("service_level_objective_%s", sloID),
 		"datadog_service_level_objective",
 		"datadog",
+		map[string]string{},
 		ServiceLevelObjectiveAllowEmptyValues,
+		additionalFields,
 	)
 }
 
@@ -85,3 +94,31 @@ func (g *ServiceLevelObjectiveGenerator) InitResources() error {
 	g.Resources = g.createResources(slos)
 	return nil
 }
+
+// sloSLIAttributes list the mutually exclusive attributes specifying the SLI of each SLO type
+var sloSLIAttributes = map[string][]string{
+	"monitor":    {"monitor_ids", "groups"},
+	"metric":     {"query"},
+	"time_slice": {"sli_specification"},
+}
+
+// PostConvertHook keep only the SLI specification matching the SLO type:
+// monitor_ids and groups for monitor SLOs, query for metric SLOs and
+// sli_specification for time slice SLOs
+func (g *ServiceLevelObjectiveGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		sloType := r.InstanceState.Attributes["type"]
+		if _, ok := sloSLIAttributes[sloType]; !ok {
+			continue
+		}
+		for otherType, attributes := range sloSLIAttributes {
+			if otherType == sloType {
+				continue
+			}
+			for _, attribute := range attributes {
+				delete(g.Resources[i].Item, attribute)
+			}
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"reflect"
+	"testing"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestServiceLevelObjectiveGroups(t *testing.T) {
+	groups := []string{"host:foo", "host:bar"}
+	slo := datadogV1.ServiceLevelObjective{Name: "grouped slo", Type: datadogV1.SLOTYPE_MONITOR}
+	slo.SetId("abc")
+	slo.SetMonitorIds([]int64{1})
+	slo.SetGroups(groups)
+
+	g := &ServiceLevelObjectiveGenerator{}
+	resources := g.createResources([]datadogV1.ServiceLevelObjective{slo, {Name: "ungrouped slo", Type: datadogV1.SLOTYPE_MONITOR}})
+
+	if !reflect.DeepEqual(resources[0].AdditionalFields["groups"], groups) {
+		t.Errorf("groups were not mapped: %v", resources[0].AdditionalFields)
+	}
+	if _, exist := resources[1].AdditionalFields["groups"]; exist {
+		t.Errorf("ungrouped slo must not set groups: %v", resources[1].AdditionalFields)
+	}
+}
+
+func TestServiceLevelObjectiveSLISpecification(t *testing.T) {
+	// Attributes the provider may read back for any SLO type
+	newItem := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":              "slo",
+			"monitor_ids":       []interface{}{"1"},
+			"groups":            []interface{}{"host:foo"},
+			"query":             []interface{}{map[string]interface{}{"numerator": "sum:good{*}", "denominator": "sum:total{*}"}},
+			"sli_specification": []interface{}{map[string]interface{}{"time_slice": []interface{}{}}},
+		}
+	}
+
+	for sloType, expected := range map[string][]string{
+		"monitor":    {"monitor_ids", "groups"},
+		"metric":     {"query"},
+		"time_slice": {"sli_specification"},
+	} {
+		g := &ServiceLevelObjectiveGenerator{}
+		resource := g.createResource("abc", nil)
+		resource.InstanceState.Attributes = map[string]string{"type": sloType}
+		resource.Item = newItem()
+		g.Resources = []terraformutils.Resource{resource}
+		if err := g.PostConvertHook(); err != nil {
+			t.Fatal(err)
+		}
+
+		var present []string
+		for _, attribute := range []string{"monitor_ids", "groups", "query", "sli_specification"} {
+			if _, exist := g.Resources[0].Item[attribute]; exist {
+				present = append(present, attribute)
+			}
+		}
+		if !reflect.DeepEqual(present, expected) {
+			t.Errorf("%s SLO: expected SLI attributes %v, got %v", sloType, expected, present)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// SLOCorrectionAllowEmptyValues ...
+	SLOCorrectionAllowEmptyValues = []string{}
+)
+
+type sloCorrection struct {
+	ID string `json:"id"`
+}
+
+type sloCorrectionsResponse struct {
+	Data []sloCorrection `json:"data"`
+}
+
+// listSLOCorrections page through the SLO corrections API, the client
+// ListSLOCorrection doesn't expose the offset and limit parameters
+func listSLOCorrections(client *datadogV1.APIClient, auth context.Context) ([]sloCorrection, error) {
+	var corrections []sloCorrection
+	limit := 1000
+	for offset := 0; ; offset += limit {
+		var resp sloCorrectionsResponse
+		err := getV1(client, auth, "/api/v1/slo/correction", url.Values{
+			"limit":  []string{strconv.Itoa(limit)},
+			"offset": []string{strconv.Itoa(offset)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, resp.Data...)
+		if len(resp.Data) < limit {
+			return corrections, nil
+		}
+	}
+}
+
+// SLOCorrectionGenerator ...
+type SLOCorrectionGenerator struct {
+	DatadogService
+}
+
+func (g *SLOCorrectionGenerator) createResources(corrections []sloCorrection) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, correction := range corrections {
+		resources = append(resources, g.createResource(correction.ID))
+	}
+
+	return resources
+}
+
+func (g *SLOCorrectionGenerator) createResource(correctionID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		correctionID,
+		fmt.Sprintf("slo_correction_%s", correctionID),
+		"datadog_slo_correction",
+		"datadog",
+		SLOCorrectionAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each SLO correction create 1 TerraformResource.
+// Need SLO Correction ID as ID for terraform resource
+func (g *SLOCorrectionGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("slo_correction") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	corrections, err := listSLOCorrections(datadogClientV1, authV1)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(corrections)
+	return nil
+}
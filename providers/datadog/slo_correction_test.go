@@ -0,0 +1,57 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSLOCorrectionPagination(t *testing.T) {
+	total := 1500
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/slo/correction" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var data []string
+		for i := offset; i < total && i < offset+limit; i++ {
+			data = append(data, fmt.Sprintf(`{"id": "correction-%d", "type": "correction", "attributes": {"slo_id": "abc", "category": "Scheduled Maintenance"}}`, i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [` + strings.Join(data, ",") + `]}`))
+	}))
+
+	g := &SLOCorrectionGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Resources) != total {
+		t.Fatalf("expected %d corrections, got %d", total, len(g.Resources))
+	}
+	last := g.Resources[total-1]
+	if last.InstanceState.ID != "correction-1499" || last.InstanceInfo.Type != "datadog_slo_correction" {
+		t.Errorf("unexpected last correction %s %s", last.InstanceInfo.Type, last.InstanceState.ID)
+	}
+}
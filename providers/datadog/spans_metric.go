@@ -0,0 +1,101 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// SpansMetricAllowEmptyValues ...
+	SpansMetricAllowEmptyValues = []string{"filter.", "group_by."}
+)
+
+type spansMetric struct {
+	ID string `json:"id"`
+}
+
+type spansMetricsResponse struct {
+	Data []spansMetric `json:"data"`
+}
+
+type spansMetricResponse struct {
+	Data spansMetric `json:"data"`
+}
+
+// SpansMetricGenerator ...
+type SpansMetricGenerator struct {
+	DatadogService
+}
+
+func (g *SpansMetricGenerator) createResources(spansMetrics []spansMetric) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, spansMetric := range spansMetrics {
+		resources = append(resources, g.createResource(spansMetric.ID))
+	}
+
+	return resources
+}
+
+func (g *SpansMetricGenerator) createResource(spansMetricID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		spansMetricID,
+		spansMetricID,
+		"datadog_spans_metric",
+		"datadog",
+		SpansMetricAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each spans metric create 1 TerraformResource, the compute block is
+// rebuilt from the state once all services are imported.
+// Need SpansMetric ID as ID for terraform resource
+func (g *SpansMetricGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("spans_metric") {
+			for _, value := range filter.AcceptableValues {
+				var resp spansMetricResponse
+				err := getV2(datadogClientV2, authV2, fmt.Sprintf("/api/v2/apm/config/metrics/%s", url.PathEscape(value)), url.Values{}, &resp)
+				if err != nil {
+					return err
+				}
+				resources = append(resources, g.createResource(resp.Data.ID))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	var resp spansMetricsResponse
+	if err := getV2(datadogClientV2, authV2, "/api/v2/apm/config/metrics", url.Values{}, &resp); err != nil {
+		return err
+	}
+	g.Resources = g.createResources(resp.Data)
+	return nil
+}
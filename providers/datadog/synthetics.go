@@ -17,6 +17,10 @@ package datadog
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
 
 	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 
@@ -31,19 +35,62 @@ var (
 // SyntheticsGenerator ...
 type SyntheticsGenerator struct {
 	DatadogService
+	options map[string]syntheticsTestOptions
 }
 
-func (g *SyntheticsGenerator) createResources(syntheticsList []datadogV1.SyntheticsTestDetails) []terraformutils.Resource {
+// syntheticsTest is a synthetics test as returned by the API, with the
+// options not covered yet by datadog-api-client-go
+type syntheticsTest struct {
+	PublicID string                `json:"public_id"`
+	Type     string                `json:"type"`
+	Options  syntheticsTestOptions `json:"options"`
+}
+
+type syntheticsTestOptions struct {
+	TickEvery          *int64 `json:"tick_every"`
+	MinFailureDuration *int64 `json:"min_failure_duration"`
+	MinLocationFailed  *int64 `json:"min_location_failed"`
+	Retry              *struct {
+		Count    *int64   `json:"count"`
+		Interval *float64 `json:"interval"`
+	} `json:"retry"`
+	MonitorOptions *struct {
+		RenotifyInterval *int64 `json:"renotify_interval"`
+	} `json:"monitor_options"`
+	CI *struct {
+		ExecutionRule string `json:"executionRule"`
+	} `json:"ci"`
+	RUMSettings *struct {
+		IsEnabled     bool   `json:"isEnabled"`
+		ApplicationID string `json:"applicationId"`
+		ClientTokenID *int64 `json:"clientTokenId"`
+	} `json:"rumSettings"`
+}
+
+type syntheticsTestsResponse struct {
+	Tests []syntheticsTest `json:"tests"`
+}
+
+func (g *SyntheticsGenerator) createResources(syntheticsList []syntheticsTest) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
 	for _, synthetics := range syntheticsList {
-		resourceName := synthetics.GetPublicId()
-		resources = append(resources, g.createResource(resourceName))
+		resources = append(resources, g.createResource(synthetics))
 	}
 
 	return resources
 }
 
-func (g *SyntheticsGenerator) createResource(syntheticsID string) terraformutils.Resource {
+func (g *SyntheticsGenerator) createResource(synthetics syntheticsTest) terraformutils.Resource {
+	if g.options == nil {
+		g.options = map[string]syntheticsTestOptions{}
+	}
+	syntheticsID := synthetics.PublicID
+	options := synthetics.Options
+	if synthetics.Type != "browser" {
+		options.RUMSettings = nil
+	}
+	g.options[syntheticsID] = options
+
 	return terraformutils.NewSimpleResource(
 		syntheticsID,
 		fmt.Sprintf("synthetics_%s", syntheticsID),
@@ -64,12 +111,13 @@ func (g *SyntheticsGenerator) InitResources() error {
 	for _, filter := range g.Filter {
 		if filter.FieldPath == "id" && filter.IsApplicable("synthetics") {
 			for _, value := range filter.AcceptableValues {
-				syntheticsTest, _, err := datadogClientV1.SyntheticsApi.GetTest(authV1, value).Execute()
+				var syntheticsTest syntheticsTest
+				err := getV1(datadogClientV1, authV1, "/api/v1/synthetics/tests/"+url.PathEscape(value), nil, &syntheticsTest)
 				if err != nil {
 					return err
 				}
 
-				resources = append(resources, g.createResource(syntheticsTest.GetPublicId()))
+				resources = append(resources, g.createResource(syntheticsTest))
 			}
 		}
 	}
@@ -79,10 +127,126 @@ func (g *SyntheticsGenerator) InitResources() error {
 		return nil
 	}
 
-	syntheticsTests, _, err := datadogClientV1.SyntheticsApi.ListTests(authV1).Execute()
+	var syntheticsTests syntheticsTestsResponse
+	err := getV1(datadogClientV1, authV1, "/api/v1/synthetics/tests", nil, &syntheticsTests)
 	if err != nil {
 		return err
 	}
-	g.Resources = g.createResources(syntheticsTests.GetTests())
+	if concurrency, ok := g.Args["synthetics-concurrency"].(int); ok && concurrency > 0 {
+		publicIDs := make([]string, 0, len(syntheticsTests.Tests))
+		for _, syntheticsTest := range syntheticsTests.Tests {
+			publicIDs = append(publicIDs, syntheticsTest.PublicID)
+		}
+		syntheticsTests.Tests, err = fetchSyntheticsTests(datadogClientV1, authV1, publicIDs, concurrency)
+		if err != nil {
+			return err
+		}
+	}
+	g.Resources = g.createResources(syntheticsTests.Tests)
 	return nil
 }
+
+// fetchSyntheticsTests fetch the details of the tests with concurrency
+// parallel requests, sorted by public id whatever order the requests complete in
+func fetchSyntheticsTests(client *datadogV1.APIClient, auth context.Context, publicIDs []string, concurrency int) ([]syntheticsTest, error) {
+	syntheticsTests := make([]syntheticsTest, len(publicIDs))
+	errs := make([]error, len(publicIDs))
+	input := make(chan int, len(publicIDs))
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range input {
+				errs[j] = getV1(client, auth, "/api/v1/synthetics/tests/"+url.PathEscape(publicIDs[j]), nil, &syntheticsTests[j])
+			}
+		}()
+	}
+	for i := range publicIDs {
+		input <- i
+	}
+	close(input)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(syntheticsTests, func(i, j int) bool {
+		return syntheticsTests[i].PublicID < syntheticsTests[j].PublicID
+	})
+	return syntheticsTests, nil
+}
+
+// PostConvertHook complete options_list with the options returned by the API
+// which are missing from the state: retries, monitor options, alerting
+// thresholds, CI execution rule and the RUM settings of browser tests.
+func (g *SyntheticsGenerator) PostConvertHook() error {
+	for i, r := range g.Resources {
+		options, ok := g.options[r.InstanceState.ID]
+		if !ok {
+			continue
+		}
+		optionsList, _ := r.Item["options_list"].([]interface{})
+		if len(optionsList) == 0 {
+			optionsList = []interface{}{map[string]interface{}{}}
+		}
+		item, ok := optionsList[0].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		setMissing := func(key string, value interface{}) {
+			if _, exist := item[key]; !exist {
+				item[key] = value
+			}
+		}
+
+		if options.TickEvery != nil {
+			setMissing("tick_every", strconv.FormatInt(*options.TickEvery, 10))
+		}
+		if options.MinFailureDuration != nil {
+			setMissing("min_failure_duration", strconv.FormatInt(*options.MinFailureDuration, 10))
+		}
+		if options.MinLocationFailed != nil {
+			setMissing("min_location_failed", strconv.FormatInt(*options.MinLocationFailed, 10))
+		}
+		if options.Retry != nil {
+			retry := map[string]interface{}{}
+			if options.Retry.Count != nil {
+				retry["count"] = strconv.FormatInt(*options.Retry.Count, 10)
+			}
+			if options.Retry.Interval != nil {
+				retry["interval"] = strconv.FormatFloat(*options.Retry.Interval, 'f', -1, 64)
+			}
+			setMissing("retry", []interface{}{retry})
+		}
+		if options.MonitorOptions != nil && options.MonitorOptions.RenotifyInterval != nil {
+			setMissing("monitor_options", []interface{}{map[string]interface{}{
+				"renotify_interval": strconv.FormatInt(*options.MonitorOptions.RenotifyInterval, 10),
+			}})
+		}
+		if options.CI != nil && options.CI.ExecutionRule != "" {
+			setMissing("ci", []interface{}{map[string]interface{}{
+				"execution_rule": options.CI.ExecutionRule,
+			}})
+		}
+		if options.RUMSettings != nil {
+			rumSettings := map[string]interface{}{
+				"is_enabled": strconv.FormatBool(options.RUMSettings.IsEnabled),
+			}
+			if options.RUMSettings.ApplicationID != "" {
+				rumSettings["application_id"] = options.RUMSettings.ApplicationID
+			}
+			if options.RUMSettings.ClientTokenID != nil {
+				rumSettings["client_token_id"] = strconv.FormatInt(*options.RUMSettings.ClientTokenID, 10)
+			}
+			setMissing("rum_settings", []interface{}{rumSettings})
+		}
+
+		if len(item) > 0 {
+			g.Resources[i].Item["options_list"] = optionsList
+		}
+	}
+	return g.DatadogService.PostConvertHook()
+}
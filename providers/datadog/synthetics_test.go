@@ -0,0 +1,122 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSyntheticsOptionsList(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/synthetics/tests" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tests": [{"public_id": "abc-def-ghi", "type": "api", "options": {
+			"tick_every": 60,
+			"min_location_failed": 2,
+			"retry": {"count": 3, "interval": 500.5},
+			"rumSettings": {"isEnabled": true}
+		}}]}`))
+	}))
+
+	g := &SyntheticsGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	// the state only holds tick_every
+	g.Resources[0].InstanceState.Attributes = map[string]string{
+		"id":                        "abc-def-ghi",
+		"type":                      "api",
+		"options_list.#":            "1",
+		"options_list.0.tick_every": "60",
+	}
+	impliedType := cty.Object(map[string]cty.Type{
+		"type": cty.String,
+		"options_list": cty.List(cty.Object(map[string]cty.Type{
+			"tick_every":          cty.Number,
+			"min_location_failed": cty.Number,
+			"retry": cty.List(cty.Object(map[string]cty.Type{
+				"count":    cty.Number,
+				"interval": cty.Number,
+			})),
+		})),
+	})
+	parseTestState(t, &g.Resources[0], impliedType)
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	options := g.Resources[0].Item["options_list"].([]interface{})[0].(map[string]interface{})
+	if options["min_location_failed"] != "2" {
+		t.Errorf("expected min_location_failed 2, got %v", options["min_location_failed"])
+	}
+	expectedRetry := []interface{}{map[string]interface{}{"count": "3", "interval": "500.5"}}
+	if !reflect.DeepEqual(options["retry"], expectedRetry) {
+		t.Errorf("expected retry %v, got %v", expectedRetry, options["retry"])
+	}
+	if _, exist := options["rum_settings"]; exist {
+		t.Errorf("rum_settings must only be set on browser tests, got %v", options["rum_settings"])
+	}
+}
+
+func TestSyntheticsConcurrentFetchOrder(t *testing.T) {
+	// The first listed tests answer last, so the fetches complete in reverse order
+	delays := map[string]time.Duration{"ccc-333": 40 * time.Millisecond, "aaa-111": 20 * time.Millisecond, "bbb-222": 0}
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/synthetics/tests" {
+			_, _ = w.Write([]byte(`{"tests": [{"public_id": "ccc-333"}, {"public_id": "aaa-111"}, {"public_id": "bbb-222"}]}`))
+			return
+		}
+		publicID := strings.TrimPrefix(r.URL.Path, "/api/v1/synthetics/tests/")
+		delay, ok := delays[publicID]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(`{"public_id": "` + publicID + `", "type": "api"}`))
+	}))
+
+	for run := 0; run < 2; run++ {
+		g := &SyntheticsGenerator{}
+		g.SetArgs(map[string]interface{}{
+			"authV1":                 auth,
+			"datadogClientV1":        client,
+			"synthetics-concurrency": 3,
+		})
+		if err := g.InitResources(); err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+		for _, r := range g.Resources {
+			ids = append(ids, r.InstanceState.ID)
+		}
+		if expected := []string{"aaa-111", "bbb-222", "ccc-333"}; !reflect.DeepEqual(ids, expected) {
+			t.Errorf("run %d: expected tests sorted by public id %v, got %v", run, expected, ids)
+		}
+	}
+}
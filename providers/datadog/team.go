@@ -0,0 +1,116 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// TeamAllowEmptyValues ...
+	TeamAllowEmptyValues = []string{}
+)
+
+type team struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name   string `json:"name"`
+		Handle string `json:"handle"`
+	} `json:"attributes"`
+}
+
+type teamsResponse struct {
+	Data []team `json:"data"`
+}
+
+// listTeams page through the V2 teams API
+func listTeams(client *datadogV2.APIClient, auth context.Context) ([]team, error) {
+	var teams []team
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		var resp teamsResponse
+		err := getV2(client, auth, "/api/v2/team", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return teams, nil
+		}
+	}
+}
+
+// TeamGenerator ...
+type TeamGenerator struct {
+	DatadogService
+}
+
+func (g *TeamGenerator) createResources(teams []team) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, team := range teams {
+		resources = append(resources, g.createResource(team.ID))
+	}
+
+	return resources
+}
+
+func (g *TeamGenerator) createResource(teamID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		teamID,
+		fmt.Sprintf("team_%s", teamID),
+		"datadog_team",
+		"datadog",
+		TeamAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each team create 1 TerraformResource.
+// Need Team ID as ID for terraform resource
+func (g *TeamGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("team") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	teams, err := listTeams(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	g.Resources = g.createResources(teams)
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// TeamMembershipAllowEmptyValues ...
+	TeamMembershipAllowEmptyValues = []string{}
+)
+
+type teamMembership struct {
+	Relationships struct {
+		User struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"user"`
+	} `json:"relationships"`
+}
+
+type teamMembershipsResponse struct {
+	Data []teamMembership `json:"data"`
+}
+
+// listTeamMemberships page through the memberships of a team, a team can have
+// thousands of members or none
+func listTeamMemberships(client *datadogV2.APIClient, auth context.Context, teamID string) ([]teamMembership, error) {
+	var memberships []teamMembership
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		var resp teamMembershipsResponse
+		err := getV2(client, auth, fmt.Sprintf("/api/v2/team/%s/memberships", url.PathEscape(teamID)), url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return memberships, nil
+		}
+	}
+}
+
+// TeamMembershipGenerator ...
+type TeamMembershipGenerator struct {
+	DatadogService
+}
+
+func (g *TeamMembershipGenerator) createResource(teamID, userID string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		fmt.Sprintf("%s:%s", teamID, userID),
+		fmt.Sprintf("team_membership_%s_%s", teamID, userID),
+		"datadog_team_membership",
+		"datadog",
+		TeamMembershipAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each member of each team create 1 TerraformResource.
+// Need Team ID and User ID joined by ":" as ID for terraform resource
+func (g *TeamMembershipGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	teams, err := listTeams(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	resources := []terraformutils.Resource{}
+	seen := map[string]bool{}
+	for _, team := range teams {
+		memberships, err := listTeamMemberships(datadogClientV2, authV2, team.ID)
+		if err != nil {
+			return err
+		}
+		for _, membership := range memberships {
+			// The pages of a roster being edited during the export can
+			// overlap, each membership is imported once
+			resource := g.createResource(team.ID, membership.Relationships.User.Data.ID)
+			if seen[resource.InstanceState.ID] {
+				continue
+			}
+			seen[resource.InstanceState.ID] = true
+			resources = append(resources, resource)
+		}
+	}
+	g.Resources = resources
+	return nil
+}
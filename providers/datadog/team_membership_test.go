@@ -0,0 +1,68 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTeamMembershipPagination(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/team":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "large", "type": "team", "attributes": {"handle": "large"}},
+				{"id": "empty", "type": "team", "attributes": {"handle": "empty"}}
+			]}`))
+		case "/api/v2/team/large/memberships":
+			if r.URL.Query().Get("page[number]") != "0" {
+				// the last member of the first page moved to the second one
+				_, _ = w.Write([]byte(`{"data": [
+					{"id": "m99", "type": "team_memberships", "relationships": {"user": {"data": {"id": "user-99", "type": "users"}}}},
+					{"id": "m100", "type": "team_memberships", "relationships": {"user": {"data": {"id": "user-100", "type": "users"}}}}
+				]}`))
+				return
+			}
+			memberships := make([]string, 100)
+			for i := range memberships {
+				memberships[i] = fmt.Sprintf(`{"id": "m%d", "type": "team_memberships", "relationships": {"user": {"data": {"id": "user-%d", "type": "users"}}}}`, i, i)
+			}
+			_, _ = w.Write([]byte(`{"data": [` + strings.Join(memberships, ",") + `]}`))
+		case "/api/v2/team/empty/memberships":
+			_, _ = w.Write([]byte(`{"data": []}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+
+	g := &TeamMembershipGenerator{}
+	g.SetArgs(map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	})
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Resources) != 101 {
+		t.Fatalf("expected the 101 distinct members of both pages, got %d", len(g.Resources))
+	}
+	if last := g.Resources[100]; last.InstanceState.ID != "large:user-100" || last.InstanceInfo.Type != "datadog_team_membership" {
+		t.Errorf("unexpected team membership %s %s", last.InstanceInfo.Type, last.InstanceState.ID)
+	}
+}
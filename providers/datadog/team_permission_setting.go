@@ -0,0 +1,88 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// TeamPermissionSettingAllowEmptyValues ...
+	TeamPermissionSettingAllowEmptyValues = []string{}
+)
+
+type teamPermissionSetting struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Action string `json:"action"`
+	} `json:"attributes"`
+}
+
+type teamPermissionSettingsResponse struct {
+	Data []teamPermissionSetting `json:"data"`
+}
+
+// TeamPermissionSettingGenerator ...
+type TeamPermissionSettingGenerator struct {
+	DatadogService
+}
+
+func (g *TeamPermissionSettingGenerator) createResource(teamID string, setting teamPermissionSetting) terraformutils.Resource {
+	// The setting is read back from its team and action
+	return terraformutils.NewResource(
+		setting.ID,
+		fmt.Sprintf("team_permission_setting_%s_%s", teamID, setting.Attributes.Action),
+		"datadog_team_permission_setting",
+		"datadog",
+		map[string]string{
+			"team_id": teamID,
+			"action":  setting.Attributes.Action,
+		},
+		TeamPermissionSettingAllowEmptyValues,
+		map[string]interface{}{},
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each permission setting of each team create 1 TerraformResource.
+// Need Team Permission Setting ID as ID for terraform resource
+func (g *TeamPermissionSettingGenerator) InitResources() error {
+	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
+	authV2 := g.Args["authV2"].(context.Context)
+
+	teams, err := listTeams(datadogClientV2, authV2)
+	if err != nil {
+		return err
+	}
+	resources := []terraformutils.Resource{}
+	for _, team := range teams {
+		var resp teamPermissionSettingsResponse
+		err := getV2(datadogClientV2, authV2, fmt.Sprintf("/api/v2/team/%s/permission-settings", url.PathEscape(team.ID)), url.Values{}, &resp)
+		if err != nil {
+			return err
+		}
+		for _, setting := range resp.Data {
+			resources = append(resources, g.createResource(team.ID, setting))
+		}
+	}
+	g.Resources = resources
+	return nil
+}
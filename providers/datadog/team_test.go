@@ -0,0 +1,70 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTeamsAndPermissionSettings(t *testing.T) {
+	client, auth := newTestClientV2(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/team":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "team-1", "type": "team", "attributes": {"handle": "checkout", "name": "Checkout", "description": "Cart and payments"}},
+				{"id": "team-2", "type": "team", "attributes": {"handle": "search", "name": "Search"}}
+			]}`))
+		case "/api/v2/team/team-1/permission-settings":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "setting-1", "type": "team_permission_settings", "attributes": {"action": "manage_membership", "value": "admins"}},
+				{"id": "setting-2", "type": "team_permission_settings", "attributes": {"action": "edit", "value": "members"}}
+			]}`))
+		case "/api/v2/team/team-2/permission-settings":
+			_, _ = w.Write([]byte(`{"data": []}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	args := map[string]interface{}{
+		"authV2":          auth,
+		"datadogClientV2": client,
+	}
+
+	teams := &TeamGenerator{}
+	teams.SetArgs(args)
+	if err := teams.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(teams.Resources) != 2 || teams.Resources[0].InstanceState.ID != "team-1" || teams.Resources[0].InstanceInfo.Type != "datadog_team" {
+		t.Fatalf("unexpected teams %v", teams.Resources)
+	}
+
+	settings := &TeamPermissionSettingGenerator{}
+	settings.SetArgs(args)
+	if err := settings.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(settings.Resources) != 2 {
+		t.Fatalf("expected 2 permission settings, got %v", settings.Resources)
+	}
+	for i, action := range []string{"manage_membership", "edit"} {
+		attributes := settings.Resources[i].InstanceState.Attributes
+		if attributes["team_id"] != "team-1" || attributes["action"] != action {
+			t.Errorf("expected the team-1 %s setting, got %v", action, attributes)
+		}
+	}
+}
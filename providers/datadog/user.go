@@ -16,7 +16,12 @@ package datadog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
 	datadogV2 "github.com/DataDog/datadog-api-client-go/api/v2/datadog"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -69,6 +74,15 @@ func (g *UserGenerator) InitResources() error {
 	datadogClientV2 := g.Args["datadogClientV2"].(*datadogV2.APIClient)
 	authV2 := g.Args["authV2"].(context.Context)
 
+	if since, ok := g.Args["modified-since"].(time.Time); ok && !since.IsZero() {
+		users, err := g.listModifiedUsers(datadogClientV2, authV2)
+		if err != nil {
+			return err
+		}
+		g.Resources = g.createResources(users)
+		return nil
+	}
+
 	pageSize := int64(1000)
 	pageNumber := int64(0)
 	remaining := int64(1)
@@ -87,3 +101,61 @@ func (g *UserGenerator) InitResources() error {
 	g.Resources = g.createResources(users)
 	return nil
 }
+
+// listModifiedUsers page through the users from the most recently modified,
+// stopping at the first one not modified since the last incremental run. The
+// API client doesn't know of the user modification time.
+func (g *UserGenerator) listModifiedUsers(client *datadogV2.APIClient, auth context.Context) ([]datadogV2.User, error) {
+	var users []datadogV2.User
+	pageSize := 1000
+	for pageNumber := 0; ; pageNumber++ {
+		var resp struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		err := getV2(client, auth, "/api/v2/users", url.Values{
+			"page[size]":   []string{strconv.Itoa(pageSize)},
+			"page[number]": []string{strconv.Itoa(pageNumber)},
+			"sort":         []string{"modified_at"},
+			"sort_dir":     []string{string(datadogV2.QUERYSORTORDER_DESC)},
+		}, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, data := range resp.Data {
+			var modified struct {
+				Attributes struct {
+					ModifiedAt time.Time `json:"modified_at"`
+				} `json:"attributes"`
+			}
+			if err := json.Unmarshal(data, &modified); err != nil {
+				return nil, err
+			}
+			if !g.isModified(modified.Attributes.ModifiedAt) {
+				return users, nil
+			}
+			var user datadogV2.User
+			if err := json.Unmarshal(data, &user); err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		}
+		if len(resp.Data) < pageSize {
+			return users, nil
+		}
+	}
+}
+
+// currentUserHandle return the handle of the user owning the application key
+func currentUserHandle(client *datadogV2.APIClient, auth context.Context) (string, error) {
+	var resp struct {
+		Data struct {
+			Attributes struct {
+				Handle string `json:"handle"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := getV2(client, auth, "/api/v2/current_user", url.Values{}, &resp); err != nil {
+		return "", err
+	}
+	return resp.Data.Attributes.Handle, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// WebhookAllowEmptyValues ...
+	WebhookAllowEmptyValues = []string{}
+)
+
+// webhooksIntegration is the webhooks integration configuration returned by
+// /api/v1/integration/webhooks, the webhooks API has no list endpoint
+type webhooksIntegration struct {
+	Hooks []struct {
+		Name string `json:"name"`
+	} `json:"hooks"`
+	CustomVariables []struct {
+		Name string `json:"name"`
+	} `json:"custom_variables"`
+}
+
+// getWebhooksIntegration fetch the webhooks and custom variables of the
+// webhooks integration
+func getWebhooksIntegration(client *datadogV1.APIClient, auth context.Context) (webhooksIntegration, error) {
+	var integration webhooksIntegration
+	err := getV1(client, auth, "/api/v1/integration/webhooks", url.Values{}, &integration)
+	return integration, err
+}
+
+// WebhookGenerator ...
+type WebhookGenerator struct {
+	DatadogService
+}
+
+func (g *WebhookGenerator) createResource(webhookName string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		webhookName,
+		webhookName,
+		"datadog_webhook",
+		"datadog",
+		WebhookAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each webhook create 1 TerraformResource.
+// Need Webhook Name as ID for terraform resource
+func (g *WebhookGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("webhook") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	integration, err := getWebhooksIntegration(datadogClientV1, authV1)
+	if err != nil {
+		return err
+	}
+	for _, hook := range integration.Hooks {
+		resources = append(resources, g.createResource(hook.Name))
+	}
+	g.Resources = resources
+	return nil
+}
+
+// linkWebhookCustomVariables make each webhook depend on the custom variables
+// of resources it uses as $NAME in its url, payload or headers, Terraform
+// can't infer the dependency from these textual references
+func linkWebhookCustomVariables(importedResource map[string][]terraformutils.Resource, isServicePath bool) {
+	var customVariables []terraformutils.Resource
+	var customVariableServices []string
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type == "datadog_webhook_custom_variable" {
+				customVariables = append(customVariables, r)
+				customVariableServices = append(customVariableServices, service)
+			}
+		}
+	}
+	if len(customVariables) == 0 {
+		return
+	}
+	for service, resources := range importedResource {
+		for _, r := range resources {
+			if r.InstanceInfo.Type != "datadog_webhook" {
+				continue
+			}
+			text := r.InstanceState.Attributes["url"] + r.InstanceState.Attributes["payload"] + r.InstanceState.Attributes["custom_headers"]
+			var dependsOn []interface{}
+			for i, customVariable := range customVariables {
+				if strings.Contains(text, "$"+customVariable.InstanceState.Attributes["name"]) {
+					dependsOn = appendAddress(dependsOn, connectedAddress(service, customVariableServices[i], customVariable, isServicePath))
+				}
+			}
+			if len(dependsOn) > 0 {
+				r.Item["depends_on"] = dependsOn
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"context"
+
+	datadogV1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+var (
+	// WebhookCustomVariableAllowEmptyValues ...
+	WebhookCustomVariableAllowEmptyValues = []string{}
+)
+
+// WebhookCustomVariableGenerator ...
+type WebhookCustomVariableGenerator struct {
+	DatadogService
+}
+
+func (g *WebhookCustomVariableGenerator) createResource(customVariableName string) terraformutils.Resource {
+	return terraformutils.NewSimpleResource(
+		customVariableName,
+		customVariableName,
+		"datadog_webhook_custom_variable",
+		"datadog",
+		WebhookCustomVariableAllowEmptyValues,
+	)
+}
+
+// InitResources Generate TerraformResources from Datadog API,
+// from each webhook custom variable create 1 TerraformResource, the secret
+// values are not returned and are replaced by variables when printed.
+// Need Custom Variable Name as ID for terraform resource
+func (g *WebhookCustomVariableGenerator) InitResources() error {
+	resources := []terraformutils.Resource{}
+	for _, filter := range g.Filter {
+		if filter.FieldPath == "id" && filter.IsApplicable("webhook_custom_variable") {
+			for _, value := range filter.AcceptableValues {
+				resources = append(resources, g.createResource(value))
+			}
+		}
+	}
+
+	if len(resources) > 0 {
+		g.Resources = resources
+		return nil
+	}
+
+	datadogClientV1 := g.Args["datadogClientV1"].(*datadogV1.APIClient)
+	authV1 := g.Args["authV1"].(context.Context)
+
+	integration, err := getWebhooksIntegration(datadogClientV1, authV1)
+	if err != nil {
+		return err
+	}
+	for _, customVariable := range integration.CustomVariables {
+		resources = append(resources, g.createResource(customVariable.Name))
+	}
+	g.Resources = resources
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadog
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestWebhookGenerators(t *testing.T) {
+	client, auth := newTestClientV1(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/integration/webhooks" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"hooks": [{"name": "alerts", "url": "https://example.com/$TOKEN"}],
+			"custom_variables": [{"name": "TOKEN", "is_secret": true}, {"name": "REGION", "value": "eu", "is_secret": false}]
+		}`))
+	}))
+	args := map[string]interface{}{
+		"authV1":          auth,
+		"datadogClientV1": client,
+	}
+
+	webhooks := &WebhookGenerator{}
+	webhooks.SetArgs(args)
+	if err := webhooks.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks.Resources) != 1 || webhooks.Resources[0].InstanceState.ID != "alerts" {
+		t.Errorf("unexpected webhooks %v", webhooks.Resources)
+	}
+
+	customVariables := &WebhookCustomVariableGenerator{}
+	customVariables.SetArgs(args)
+	if err := customVariables.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if len(customVariables.Resources) != 2 || customVariables.Resources[0].InstanceInfo.Type != "datadog_webhook_custom_variable" {
+		t.Errorf("unexpected webhook custom variables %v", customVariables.Resources)
+	}
+}
+
+func TestWebhookCustomVariableSecrets(t *testing.T) {
+	newCustomVariable := func(name, value, isSecret string) terraformutils.Resource {
+		customVariable := terraformutils.NewResource(name, name, "datadog_webhook_custom_variable", "datadog", map[string]string{
+			"id":        name,
+			"name":      name,
+			"value":     value,
+			"is_secret": isSecret,
+		}, WebhookCustomVariableAllowEmptyValues, map[string]interface{}{})
+		customVariable.Item = map[string]interface{}{"name": name, "value": value, "is_secret": isSecret}
+		return customVariable
+	}
+	token := newCustomVariable("TOKEN", "", "true")
+	region := newCustomVariable("REGION", "eu", "false")
+	webhook := terraformutils.NewResource("alerts", "alerts", "datadog_webhook", "datadog", map[string]string{
+		"id":   "alerts",
+		"name": "alerts",
+		"url":  "https://example.com/$TOKEN",
+	}, WebhookAllowEmptyValues, map[string]interface{}{})
+	webhook.Item = map[string]interface{}{"name": "alerts", "url": "https://example.com/$TOKEN"}
+
+	p := &DatadogProvider{}
+	if _, err := p.PrintHook(t.TempDir(), "hcl", []terraformutils.Resource{token, region, webhook}); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := token.Item["value"]; value != "${var.TOKEN_value}" {
+		t.Errorf("expected the secret value to reference its variable, got %v", value)
+	}
+	if value := region.Item["value"]; value != "eu" {
+		t.Errorf("expected the value not secret to be kept, got %v", value)
+	}
+
+	importedResource := map[string][]terraformutils.Resource{
+		"webhook":                 {webhook},
+		"webhook_custom_variable": {token, region},
+	}
+	p.ConnectHook(importedResource, true)
+	expected := []interface{}{"data.terraform_remote_state.webhook_custom_variable"}
+	if dependsOn := webhook.Item["depends_on"]; !reflect.DeepEqual(dependsOn, expected) {
+		t.Errorf("expected the webhook to depend on %v, got %v", expected, dependsOn)
+	}
+	p.ConnectHook(importedResource, false)
+	expected = []interface{}{"datadog_webhook_custom_variable.tfer--TOKEN"}
+	if dependsOn := webhook.Item["depends_on"]; !reflect.DeepEqual(dependsOn, expected) {
+		t.Errorf("expected the webhook to depend on %v, got %v", expected, dependsOn)
+	}
+}
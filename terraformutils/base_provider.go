@@ -32,6 +32,46 @@ type ProviderGenerator interface {
 	GetResourceConnections() map[string]map[string][]string
 }
 
+// PostImportHook is implemented by providers which need to check or change the
+// resources of all imported services before anything is written
+type PostImportHook interface {
+	PostImportHook(importedResource map[string][]Resource) error
+}
+
+// ConnectHook is implemented by providers which link the resources of the
+// imported services in ways the resource connections can't express, as
+// references inside a value or depends_on. The resources of other services
+// are read from their remote state when isServicePath, directly otherwise.
+type ConnectHook interface {
+	ConnectHook(importedResource map[string][]Resource, isServicePath bool)
+}
+
+// PrintHook is implemented by providers which need to change the resources of
+// a service or write extra files to its output path before it is printed.
+// The returned resources are the ones printed and written to the state.
+type PrintHook interface {
+	PrintHook(path, output string, resources []Resource) ([]Resource, error)
+}
+
+// SplitPathHook is implemented by providers which spread the resources of a
+// service across several output paths, each printed as a self-contained root
+type SplitPathHook interface {
+	SplitPath(path string, resources []Resource) map[string][]Resource
+}
+
+// OutputHook is implemented by providers which need to process the files
+// written under path, the output path of the provider, once every service is
+// printed
+type OutputHook interface {
+	OutputHook(path string) error
+}
+
+// ServiceOrderHook is implemented by providers which let users choose the
+// order the requested services are imported in
+type ServiceOrderHook interface {
+	OrderServices(services []string) []string
+}
+
 type Provider struct {
 	Service ServiceGenerator
 	Config  cty.Value
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl/hcl/ast"
@@ -43,6 +44,7 @@ func (v *astSanitizer) visit(n interface{}) {
 	case *ast.File:
 		v.visit(t.Node)
 	case *ast.ObjectList:
+		t.Items = v.commentItems(t.Items)
 		var index int
 		for {
 			if index == len(t.Items) {
@@ -63,6 +65,29 @@ func (v *astSanitizer) visit(n interface{}) {
 	}
 }
 
+// commentItems turns the "//" string attributes, comments in Terraform JSON
+// syntax, into a comment above the next item
+func (v *astSanitizer) commentItems(items []*ast.ObjectItem) []*ast.ObjectItem {
+	var comments []*ast.Comment
+	result := make([]*ast.ObjectItem, 0, len(items))
+	for _, item := range items {
+		if literal, ok := item.Val.(*ast.LiteralType); ok && len(item.Keys) == 1 && item.Keys[0].Token.Text == `"//"` {
+			if text, err := strconv.Unquote(literal.Token.Text); err == nil {
+				for _, line := range strings.Split(text, "\n") {
+					comments = append(comments, &ast.Comment{Text: "# " + line})
+				}
+				continue
+			}
+		}
+		if len(comments) > 0 {
+			item.LeadComment = &ast.CommentGroup{List: comments}
+			comments = nil
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
 func (v *astSanitizer) visitObjectItem(o *ast.ObjectItem) {
 	for i, k := range o.Keys {
 		if i == 0 {
@@ -52,3 +52,20 @@ func TestPrintResource(t *testing.T) {
 		t.Errorf("failed to parse data %s", string(data))
 	}
 }
+
+func TestPrintResourceComment(t *testing.T) {
+	importResource := prepare("ID1", "type1", map[string]string{
+		"field1": "egg",
+	}, map[string]interface{}{
+		"//":     "requires provider >= 1.2.0",
+		"field1": "egg",
+	})
+	data, err := HclPrintResource([]Resource{importResource}, map[string]interface{}{}, "hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), "# requires provider >= 1.2.0\n") || strings.Contains(string(data), `"//"`) {
+		t.Errorf("expected a comment, got %s", string(data))
+	}
+}
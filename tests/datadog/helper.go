@@ -20,6 +20,10 @@ var (
 	commandTerraformOutput     = "terraform output"
 	commandTerraformV13Upgrade = "terraform 0.13upgrade -yes ."
 	datadogResourcesPath       = "tests/datadog/resources/"
+	// Resource types whose terraformer service name differs from the type
+	resourceTypeServices = map[string]string{
+		"synthetics_test": "synthetics",
+	}
 )
 
 type DatadogConfig struct {
@@ -161,10 +165,16 @@ func parseTerraformOutput(output string) *map[string][]string {
 		if len(resourceOutput) > 0 {
 			resourceArr := strings.Split(resourceOutput, " = ")
 			resourceID := resourceArr[len(resourceArr)-1]
-			// Get resource name
-			re := regexp.MustCompile("_(.*?)(--|_)")
-			match := re.FindStringSubmatch(resourceArr[0])
+			// Get resource name, the whole resource type as it may hold underscores
+			re := regexp.MustCompile("^datadog_(.*?)(_tfer)?--")
+			match := re.FindStringSubmatch(strings.TrimSpace(resourceArr[0]))
+			if match == nil {
+				continue
+			}
 			resourceName := match[1]
+			if service, ok := resourceTypeServices[resourceName]; ok {
+				resourceName = service
+			}
 
 			resources[resourceName] = append(resources[resourceName], resourceID)
 		}
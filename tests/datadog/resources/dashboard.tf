@@ -601,3 +601,21 @@ resource "datadog_dashboard" "free_dashboard_example" {
     }
   }
 }
+
+resource "datadog_role" "dashboard_role_example" {
+  name = "Terraformer dashboard editors"
+}
+
+resource "datadog_dashboard" "restricted_dashboard_example" {
+  title            = "Restricted Dashboard"
+  description      = "Created using the Datadog provider in Terraform"
+  layout_type      = "ordered"
+  notify_list      = ["new@example.com"]
+  restricted_roles = [datadog_role.dashboard_role_example.id]
+
+  widget {
+    note_definition {
+      content = "Only dashboard editors can change this dashboard"
+    }
+  }
+}
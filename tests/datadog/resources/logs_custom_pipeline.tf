@@ -0,0 +1,38 @@
+# Create a Datadog logs custom pipeline with a description and tags
+resource "datadog_logs_custom_pipeline" "logs_custom_pipeline_example" {
+  name        = "terraformer custom pipeline"
+  is_enabled  = true
+  description = "Pipeline exported by terraformer tests"
+  tags        = ["team:logs", "env:test"]
+
+  filter {
+    query = "source:terraformer"
+  }
+
+  processor {
+    status_remapper {
+      sources    = ["level"]
+      name       = "status remapper"
+      is_enabled = true
+    }
+  }
+
+  processor {
+    arithmetic_processor {
+      name               = "duration in seconds"
+      expression         = "(end - start) / 1000"
+      target             = "duration_s"
+      is_replace_missing = false
+      is_enabled         = true
+    }
+  }
+
+  processor {
+    geo_ip_parser {
+      name       = "client geo"
+      sources    = ["network.client.ip"]
+      target     = "network.client.geoip"
+      is_enabled = true
+    }
+  }
+}
@@ -7,11 +7,24 @@ output "datadog_dashboard--free_dashboard_example" {
   value = datadog_dashboard.free_dashboard_example.id
 }
 
+output "datadog_dashboard--restricted_dashboard_example" {
+  value = datadog_dashboard.restricted_dashboard_example.id
+}
+
+output "datadog_role--dashboard_role_example" {
+  value = datadog_role.dashboard_role_example.id
+}
+
 # Downtimes
 output "datadog_downtime--downtime_example" {
   value = datadog_downtime.downtime_example.id
 }
 
+# Logs custom pipelines
+output "datadog_logs_custom_pipeline--logs_custom_pipeline_example" {
+  value = datadog_logs_custom_pipeline.logs_custom_pipeline_example.id
+}
+
 # Monitors
 output "datadog_monitor--monitor_example" {
   value = datadog_monitor.monitor_example.id
@@ -31,3 +44,13 @@ output "datadog_user--user_example_two" {
   value = datadog_user.user_example_two.id
 }
 
+
+# Security monitoring rules
+output "datadog_security_monitoring_rule--security_monitoring_rule_example" {
+  value = datadog_security_monitoring_rule.security_monitoring_rule_example.id
+}
+
+# Service level objectives
+output "datadog_service_level_objective--service_level_objective_example" {
+  value = datadog_service_level_objective.service_level_objective_example.id
+}
@@ -0,0 +1,36 @@
+# Create a security monitoring rule tagged with MITRE tactic/technique and
+# enriched from a reference table. The "threat_intel_ips" reference table
+# must already exist in the test organization.
+resource "datadog_security_monitoring_rule" "security_monitoring_rule_example" {
+  name    = "Suspicious IP login"
+  message = "Login from an IP listed in threat intel"
+  enabled = true
+
+  query {
+    name            = "logins"
+    query           = "source:auth @evt.name:authentication"
+    aggregation     = "count"
+    group_by_fields = ["@usr.id"]
+  }
+
+  reference_tables {
+    table_name      = "threat_intel_ips"
+    column_name     = "ip"
+    log_field_path  = "network.client.ip"
+    rule_query_name = "logins"
+    check_presence  = true
+  }
+
+  case {
+    status    = "high"
+    condition = "logins > 0"
+  }
+
+  options {
+    evaluation_window   = 300
+    keep_alive          = 600
+    max_signal_duration = 900
+  }
+
+  tags = ["tactic:TA0001-initial-access", "technique:T1078-valid-accounts", "source:auth"]
+}
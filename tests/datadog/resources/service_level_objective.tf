@@ -0,0 +1,16 @@
+# Create a grouped monitor SLO counting two of the monitor groups
+resource "datadog_service_level_objective" "service_level_objective_example" {
+  name        = "Grouped monitor SLO"
+  type        = "monitor"
+  description = "Created using the Datadog provider in Terraform"
+  monitor_ids = [datadog_monitor.monitor_example.id]
+  groups      = ["host:foo", "host:bar"]
+
+  thresholds {
+    timeframe = "7d"
+    target    = 99.9
+    warning   = 99.99
+  }
+
+  tags = ["foo:bar", "baz"]
+}